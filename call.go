@@ -0,0 +1,192 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// PanicError describes a panic recovered when calling a function with option WithRecoverPanic
+type PanicError struct {
+	Value any // the recovered value
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("%v: %v", ErrPanicRecovered, e.Value)
+}
+
+// Is reports whether the target is ErrPanicRecovered
+func (e *PanicError) Is(target error) bool {
+	return target == ErrPanicRecovered //nolint:errorlint
+}
+
+// Unwrap returns the recovered value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// CallFunc calls a function with the given arguments. Arguments are converted to the parameter types
+// the same way as ValueAs does, and an argument can be a reflect.Value. For variadic functions,
+// the variadic arguments can be passed one by one, or as a single slice of the variadic type
+// (the same as `fn(s...)`).
+//
+// Returns ErrTypeInvalid if the input is not a function, ErrTypeUnmatched if the number of
+// the arguments is incorrect or an argument can't be converted.
+func CallFunc(fn reflect.Value, args ...any) ([]reflect.Value, error) {
+	return CallFuncWith(fn, args)
+}
+
+// CallFuncWith is the same as CallFunc, but it accepts options WithUnwrapError and WithRecoverPanic
+func CallFuncWith(fn reflect.Value, args []any, opts ...Option) ([]reflect.Value, error) {
+	val := indirectValueTilRoot(fn)
+	if !val.IsValid() || val.Kind() != reflect.Func {
+		return nil, fmt.Errorf("%w: require func type (got %v)", ErrTypeInvalid, fn.Type())
+	}
+	if val.IsNil() {
+		return nil, fmt.Errorf("%w: func is nil", ErrValueInvalid)
+	}
+	return callFunc(val, args, newOptions(opts))
+}
+
+// CallMethod calls a method by name with the given arguments (see CallFunc).
+// Methods of pointer receivers are accessible when the input is a pointer or addressable.
+//
+// Returns ErrNotFound if the method is not found.
+func CallMethod(v reflect.Value, name string, args ...any) ([]reflect.Value, error) {
+	return CallMethodWith(v, name, args)
+}
+
+// CallMethodWith is the same as CallMethod, but it accepts options WithUnwrapError and WithRecoverPanic
+func CallMethodWith(v reflect.Value, name string, args []any, opts ...Option) ([]reflect.Value, error) {
+	method, err := methodByName(v, name)
+	if err != nil {
+		return nil, err
+	}
+	return callFunc(method, args, newOptions(opts))
+}
+
+// methodByName finds a method with dereferencing pointers and interfaces till one is found
+func methodByName(v reflect.Value, name string) (reflect.Value, error) {
+	input := v
+	for v.IsValid() {
+		if (v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer) && v.IsNil() {
+			// Methods of pointer receivers are still callable on a nil pointer,
+			// but methods of value receivers panic as the pointer is dereferenced
+			if v.Kind() == reflect.Pointer {
+				if method := v.MethodByName(name); method.IsValid() {
+					if _, ok := v.Type().Elem().MethodByName(name); ok {
+						return reflect.Value{}, fmt.Errorf("%w: method '%s' of value receiver is called on nil %v",
+							ErrValueInvalid, name, v.Type())
+					}
+					return method, nil
+				}
+			}
+			break
+		}
+		if method := v.MethodByName(name); method.IsValid() {
+			return method, nil
+		}
+		if v.CanAddr() {
+			if method := v.Addr().MethodByName(name); method.IsValid() {
+				return method, nil
+			}
+		}
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			break
+		}
+		v = v.Elem()
+	}
+	return reflect.Value{}, fmt.Errorf("%w: method '%s' of %v", ErrNotFound, name, input.Type())
+}
+
+func callFunc(fn reflect.Value, args []any, opts *options) (result []reflect.Value, err error) {
+	if !fn.CanInterface() {
+		return nil, fmt.Errorf("%w: func is obtained via unexported field", ErrValueInvalid)
+	}
+	fnType := fn.Type()
+	in, spread, err := funcArgs(fnType, args)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.recoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				result, err = nil, &PanicError{Value: r}
+			}
+		}()
+	}
+	if spread {
+		result = fn.CallSlice(in)
+	} else {
+		result = fn.Call(in)
+	}
+
+	numOut := len(result)
+	if opts.unwrapError && numOut > 0 && fnType.Out(numOut-1) == errorType {
+		errVal := result[numOut-1]
+		result = result[:numOut-1]
+		if !errVal.IsNil() {
+			return result, errVal.Interface().(error) //nolint:forcetypeassert
+		}
+	}
+	return result, nil
+}
+
+// funcArgs converts the arguments to the parameter types of a function.
+// Returns true if the last argument is a slice for the variadic parameter.
+func funcArgs(fnType reflect.Type, args []any) ([]reflect.Value, bool, error) {
+	numIn := fnType.NumIn()
+	variadic := fnType.IsVariadic()
+	if (!variadic && len(args) != numIn) || (variadic && len(args) < numIn-1) {
+		atLeast := ""
+		if variadic {
+			atLeast = "at least "
+		}
+		return nil, false, fmt.Errorf("%w: func requires %s%d arguments (got %d)", ErrTypeUnmatched,
+			atLeast, numIn-boolToInt(variadic), len(args))
+	}
+
+	// The last argument is a slice of the variadic type, passes it as is
+	spread := false
+	if variadic && len(args) == numIn {
+		lastArg := argValue(args[numIn-1])
+		spread = lastArg.IsValid() && lastArg.Type().AssignableTo(fnType.In(numIn-1))
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		var paramType reflect.Type
+		switch {
+		case !variadic || i < numIn-1:
+			paramType = fnType.In(i)
+		case spread:
+			paramType = fnType.In(numIn - 1)
+		default:
+			paramType = fnType.In(numIn - 1).Elem()
+		}
+		argVal, err := valueConvert(argValue(arg), paramType, false)
+		if err != nil {
+			return nil, false, fmt.Errorf("argument %d: %w", i, err)
+		}
+		in[i] = argVal
+	}
+	return in, spread, nil
+}
+
+// argValue gets reflect.Value of an argument which can be a reflect.Value itself
+func argValue(arg any) reflect.Value {
+	if val, ok := arg.(reflect.Value); ok {
+		return val
+	}
+	return reflect.ValueOf(arg)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
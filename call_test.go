@@ -0,0 +1,215 @@
+package rflutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type callTestCounter struct {
+	N int
+}
+
+func (c callTestCounter) Get() int {
+	return c.N
+}
+
+func (c *callTestCounter) Add(delta int64) int {
+	c.N += int(delta)
+	return c.N
+}
+
+func (c *callTestCounter) AddAll(deltas ...int) (int, error) {
+	if len(deltas) == 0 {
+		return 0, errors.New("no deltas")
+	}
+	for _, d := range deltas {
+		c.N += d
+	}
+	return c.N, nil
+}
+
+func Test_CallFunc(t *testing.T) {
+	t.Run("#1: convert arguments", func(t *testing.T) {
+		type MyInt int
+		fn := func(a int64, b MyInt, c any, d []string) string {
+			return fmt.Sprintf("%v %v %v %v", a, b, c, d)
+		}
+		out, err := CallFunc(valOf(fn), 1, 2, nil, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, "1 2 <nil> []", out[0].Interface())
+
+		out, err = CallFunc(valOf(fn), int8(1), valOf(MyInt(2)), "c", []string{"d"})
+		assert.Nil(t, err)
+		assert.Equal(t, "1 2 c [d]", out[0].Interface())
+	})
+
+	t.Run("#2: variadic", func(t *testing.T) {
+		fn := func(sep string, items ...any) string {
+			return sep + fmt.Sprint(items...)
+		}
+		out, err := CallFunc(valOf(fn), ":")
+		assert.Nil(t, err)
+		assert.Equal(t, ":", out[0].Interface())
+
+		out, err = CallFunc(valOf(fn), ":", "a", 1)
+		assert.Nil(t, err)
+		assert.Equal(t, ":a1", out[0].Interface())
+
+		// A slice of the variadic type is spread
+		out, err = CallFunc(valOf(fn), ":", []any{"a", "b"})
+		assert.Nil(t, err)
+		assert.Equal(t, ":ab", out[0].Interface())
+
+		// Other slices are passed as single items
+		out, err = CallFunc(valOf(fn), ":", []string{"a", "b"})
+		assert.Nil(t, err)
+		assert.Equal(t, ":[a b]", out[0].Interface())
+
+		out, err = CallFunc(valOf(strings.Join), []string{"a", "b"}, "-")
+		assert.Nil(t, err)
+		assert.Equal(t, "a-b", out[0].Interface())
+	})
+
+	t.Run("#3: unwrap error", func(t *testing.T) {
+		errTest := errors.New("test")
+		fn := func(fail bool) (int, error) {
+			if fail {
+				return 0, errTest
+			}
+			return 1, nil
+		}
+		out, err := CallFuncWith(valOf(fn), []any{false}, WithUnwrapError())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(out))
+		assert.Equal(t, 1, out[0].Interface())
+
+		out, err = CallFuncWith(valOf(fn), []any{true}, WithUnwrapError())
+		assert.ErrorIs(t, err, errTest)
+		assert.Equal(t, 1, len(out))
+
+		// Not unwrapped without the option
+		out, err = CallFunc(valOf(fn), true)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(out))
+		assert.Equal(t, errTest, out[1].Interface())
+	})
+
+	t.Run("#4: recover panic", func(t *testing.T) {
+		fn := func() { panic("boom") }
+		_, err := CallFuncWith(valOf(fn), nil, WithRecoverPanic())
+		assert.ErrorIs(t, err, ErrPanicRecovered)
+		assert.ErrorContains(t, err, "boom")
+
+		assert.Panics(t, func() {
+			_, _ = CallFunc(valOf(fn))
+		})
+
+		errBoom := errors.New("boom")
+		_, err = CallFuncWith(valOf(func() { panic(errBoom) }), nil, WithRecoverPanic())
+		assert.ErrorIs(t, err, ErrPanicRecovered)
+		assert.ErrorIs(t, err, errBoom)
+		var panicErr *PanicError
+		assert.True(t, errors.As(err, &panicErr))
+		assert.Equal(t, errBoom, panicErr.Value)
+	})
+}
+
+func Test_CallFunc_failure(t *testing.T) {
+	t.Run("#1: input is not a func", func(t *testing.T) {
+		_, err := CallFunc(valOf(123))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: nil func", func(t *testing.T) {
+		var fn func()
+		_, err := CallFunc(valOf(fn))
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#3: number of arguments unmatched", func(t *testing.T) {
+		_, err := CallFunc(valOf(func(int, int) {}), 1)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		_, err = CallFunc(valOf(func(int, ...int) {}))
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
+
+	t.Run("#4: argument type unmatched", func(t *testing.T) {
+		_, err := CallFunc(valOf(func(int) {}), "a")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		_, err = CallFunc(valOf(func(int) {}), nil)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		_, err = CallFunc(valOf(func(...int) {}), 1, "a")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "argument 1")
+	})
+}
+
+func Test_CallMethod(t *testing.T) {
+	t.Run("#1: value receiver", func(t *testing.T) {
+		out, err := CallMethod(valOf(callTestCounter{N: 1}), "Get")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, out[0].Interface())
+	})
+
+	t.Run("#2: pointer receiver", func(t *testing.T) {
+		c := &callTestCounter{N: 1}
+		out, err := CallMethod(valOf(c), "Add", 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, out[0].Interface())
+		assert.Equal(t, 3, c.N)
+
+		// Via pointer to pointer
+		out, err = CallMethod(valOf(&c), "Get")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, out[0].Interface())
+	})
+
+	t.Run("#3: pointer receiver of addressable value", func(t *testing.T) {
+		type SS struct {
+			Counter callTestCounter
+		}
+		s := SS{}
+		_, err := CallMethodWith(reflect.ValueOf(&s).Elem().Field(0), "AddAll", []any{1, 2}, WithUnwrapError())
+		assert.Nil(t, err)
+		assert.Equal(t, 3, s.Counter.N)
+
+		_, err = CallMethodWith(reflect.ValueOf(&s).Elem().Field(0), "AddAll", nil, WithUnwrapError())
+		assert.ErrorContains(t, err, "no deltas")
+	})
+
+	t.Run("#4: method via interface", func(t *testing.T) {
+		var s fmt.Stringer = reflect.TypeOf(0)
+		out, err := CallMethod(valOf(&s), "String")
+		assert.Nil(t, err)
+		assert.Equal(t, "int", out[0].Interface())
+	})
+}
+
+func Test_CallMethod_failure(t *testing.T) {
+	t.Run("#1: method not found", func(t *testing.T) {
+		_, err := CallMethod(valOf(callTestCounter{}), "Unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#2: pointer receiver of unaddressable value", func(t *testing.T) {
+		_, err := CallMethod(valOf(callTestCounter{}), "Add", 1)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#3: nil pointer", func(t *testing.T) {
+		var c *callTestCounter
+		_, err := CallMethodWith(valOf(c), "Add", []any{1}, WithRecoverPanic())
+		assert.ErrorIs(t, err, ErrPanicRecovered)
+
+		// Methods of value receivers can't be called on nil pointers
+		_, err = CallMethod(valOf(c), "Get")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		_, err = CallMethod(valOf(&c), "Get")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+}
@@ -0,0 +1,85 @@
+// Command rfllayout prints the memory layout reports of the registered struct types,
+// with the suggested field orders minimizing the struct sizes.
+//
+// Go types can't be loaded by names at runtime, so the types to analyze are registered
+// to the registry in types.go. Usage:
+//
+//	rfllayout                    # lists the registered type names
+//	rfllayout <name> [<name>...] # prints the reports of the types
+//	rfllayout -all               # prints the reports of all registered types
+//	rfllayout -all -suboptimal   # prints the reports of the types having suboptimal field orders
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/tiendc/go-rflutil"
+)
+
+func main() {
+	if err := run(os.Stdout, os.Args[1:], registry); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, args []string, reg *rflutil.TypeRegistry) error {
+	fs := flag.NewFlagSet("rfllayout", flag.ContinueOnError)
+	fs.SetOutput(w)
+	all := fs.Bool("all", false, "print the reports of all registered types")
+	onlySuboptimal := fs.Bool("suboptimal", false, "print the reports of the types having suboptimal orders only")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	names := fs.Args()
+	if *all {
+		names = primaryNames(reg)
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(w, "registered types:")
+		for _, name := range reg.Names() {
+			fmt.Fprintln(w, "  "+name)
+		}
+		return nil
+	}
+
+	printed := 0
+	for _, name := range names {
+		typ, ok := reg.Lookup(name)
+		if !ok {
+			return fmt.Errorf("%w: type name '%s'", rflutil.ErrNotFound, name)
+		}
+		report := rflutil.Layout(typ)
+		if *onlySuboptimal && report.OptimalSize == report.Size {
+			continue
+		}
+		if printed > 0 {
+			fmt.Fprintln(w)
+		}
+		if _, err := io.WriteString(w, report.String()); err != nil {
+			return err
+		}
+		printed++
+	}
+	return nil
+}
+
+// primaryNames returns the names of the registered struct types, aliases are skipped
+func primaryNames(reg *rflutil.TypeRegistry) []string {
+	var result []string
+	for _, name := range reg.Names() {
+		typ, _ := reg.Lookup(name)
+		if typ.Kind() != reflect.Struct {
+			continue
+		}
+		if primary, err := reg.NameOf(reflect.New(typ)); err == nil && primary == name {
+			result = append(result, name)
+		}
+	}
+	return result
+}
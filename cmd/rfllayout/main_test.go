@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tiendc/go-rflutil"
+)
+
+type padded struct {
+	A bool
+	B int64
+	C bool
+}
+
+type compact struct {
+	B int64
+	A bool
+	C bool
+}
+
+func newTestRegistry(t *testing.T) *rflutil.TypeRegistry {
+	reg := rflutil.NewTypeRegistry()
+	assert.Nil(t, reg.Register(reflect.TypeOf(padded{}), "padded"))
+	assert.Nil(t, reg.Register(reflect.TypeOf(compact{}), "compact"))
+	return reg
+}
+
+func Test_run(t *testing.T) {
+	reg := newTestRegistry(t)
+
+	t.Run("#1: list names", func(t *testing.T) {
+		var sb strings.Builder
+		assert.Nil(t, run(&sb, nil, reg))
+		assert.Equal(t, "registered types:\n"+
+			"  compact\n"+
+			"  github.com/tiendc/go-rflutil/cmd/rfllayout.compact\n"+
+			"  github.com/tiendc/go-rflutil/cmd/rfllayout.padded\n"+
+			"  padded\n", sb.String())
+	})
+
+	t.Run("#2: print reports by names", func(t *testing.T) {
+		var sb strings.Builder
+		assert.Nil(t, run(&sb, []string{"padded", "compact"}, reg))
+		assert.Equal(t, "main.padded: size=24 align=8 padding=14\n"+
+			"OFFSET  SIZE  ALIGN  PADDING  FIELD\n"+
+			"0       1     1      7        A bool\n"+
+			"8       8     8      0        B int64\n"+
+			"16      1     1      7        C bool\n"+
+			"optimal order (size=16): B, A, C\n"+
+			"\n"+
+			"main.compact: size=16 align=8 padding=6\n"+
+			"OFFSET  SIZE  ALIGN  PADDING  FIELD\n"+
+			"0       8     8      0        B int64\n"+
+			"8       1     1      0        A bool\n"+
+			"9       1     1      6        C bool\n"+
+			"the field order is optimal\n", sb.String())
+	})
+
+	t.Run("#3: print suboptimal types", func(t *testing.T) {
+		var sb strings.Builder
+		assert.Nil(t, run(&sb, []string{"-all", "-suboptimal"}, reg))
+		assert.True(t, strings.HasPrefix(sb.String(), "main.padded:"))
+		assert.NotContains(t, sb.String(), "main.compact")
+	})
+
+	t.Run("#4: default registry", func(t *testing.T) {
+		var sb strings.Builder
+		assert.Nil(t, run(&sb, []string{"-all"}, registry))
+		assert.Contains(t, sb.String(), "rflutil.Tag:")
+	})
+}
+
+func Test_run_failure(t *testing.T) {
+	reg := newTestRegistry(t)
+	var sb strings.Builder
+
+	err := run(&sb, []string{"unknown"}, reg)
+	assert.ErrorIs(t, err, rflutil.ErrNotFound)
+
+	err = run(&sb, []string{"-invalid"}, reg)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"reflect"
+
+	"github.com/tiendc/go-rflutil"
+)
+
+// registry the types to analyze, register your types here
+var registry = newRegistry(
+	reflect.TypeOf(rflutil.Tag{}),
+	reflect.TypeOf(rflutil.FieldLayout{}),
+	reflect.TypeOf(rflutil.MethodInfo{}),
+	reflect.TypeOf(rflutil.DumpOptions{}),
+)
+
+func newRegistry(types ...reflect.Type) *rflutil.TypeRegistry {
+	reg := rflutil.NewTypeRegistry()
+	for _, t := range types {
+		if err := reg.Register(t); err != nil {
+			panic(err)
+		}
+	}
+	return reg
+}
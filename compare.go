@@ -0,0 +1,194 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type orderedClass int
+
+const (
+	orderedNil orderedClass = iota
+	orderedBool
+	orderedInt
+	orderedUint
+	orderedFloat
+	orderedString
+	orderedTime
+)
+
+// orderedValue a value of an ordered kind (bool, ints, uints, floats, string) or time.Time
+// extracted from a reflect.Value to compare fast
+type orderedValue struct {
+	class orderedClass
+	i     int64
+	u     uint64
+	f     float64
+	s     string
+	t     time.Time
+}
+
+// toOrderedValue extracts the value, pointers and interfaces are dereferenced.
+// Nil is allowed and considered smaller than other values.
+func toOrderedValue(v reflect.Value) (orderedValue, error) {
+	val := indirectValueTilRoot(v)
+	if !val.IsValid() {
+		return orderedValue{class: orderedNil}, nil
+	}
+	if val.Type() == timeType {
+		return orderedValue{class: orderedTime, t: val.Interface().(time.Time)}, nil //nolint:forcetypeassert
+	}
+
+	switch val.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		var i int64
+		if val.Bool() {
+			i = 1
+		}
+		return orderedValue{class: orderedBool, i: i}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return orderedValue{class: orderedInt, i: val.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return orderedValue{class: orderedUint, u: val.Uint()}, nil
+	case reflect.Float32, reflect.Float64:
+		return orderedValue{class: orderedFloat, f: val.Float()}, nil
+	case reflect.String:
+		return orderedValue{class: orderedString, s: val.String()}, nil
+	default:
+		return orderedValue{}, fmt.Errorf("%w: require ordered type or time.Time (got %v)", ErrTypeInvalid, val.Type())
+	}
+}
+
+// compare returns -1, 0, +1 when a < b, a == b, a > b respectively.
+// Values of different classes are ordered by class, callers should check them beforehand if needed.
+func (a *orderedValue) compare(b *orderedValue) int {
+	if a.class != b.class {
+		return compareOrdered(a.class, b.class)
+	}
+	switch a.class {
+	case orderedNil:
+		return 0
+	case orderedBool, orderedInt:
+		return compareOrdered(a.i, b.i)
+	case orderedUint:
+		return compareOrdered(a.u, b.u)
+	case orderedFloat:
+		return compareFloats(a.f, b.f)
+	case orderedString:
+		return strings.Compare(a.s, b.s)
+	case orderedTime:
+		switch {
+		case a.t.Before(b.t):
+			return -1
+		case a.t.After(b.t):
+			return 1
+		}
+	}
+	return 0
+}
+
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+func compareOrdered[T ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareBools compares bools with false < true
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+// compareValues compares 2 values with a total order for values of comparable types.
+// Values of different types are ordered by their kinds, then by their type names.
+// Invalid values and nil interfaces are considered smallest.
+//
+//nolint:gocognit,gocyclo
+func compareValues(a, b reflect.Value) int {
+	if !a.IsValid() || !b.IsValid() {
+		return compareBools(a.IsValid(), b.IsValid())
+	}
+	if a.Type() != b.Type() {
+		if a.Kind() != b.Kind() {
+			return compareOrdered(a.Kind(), b.Kind())
+		}
+		return strings.Compare(a.Type().String(), b.Type().String())
+	}
+
+	switch a.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return compareBools(a.Bool(), b.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareFloats(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := compareFloats(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return compareFloats(imag(a.Complex()), imag(b.Complex()))
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return compareOrdered(a.Pointer(), b.Pointer())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return compareBools(!a.IsNil(), !b.IsNil())
+		}
+		return compareValues(a.Elem(), b.Elem())
+	case reflect.Struct:
+		if a.Type() == timeType && a.CanInterface() && b.CanInterface() {
+			at, bt := a.Interface().(time.Time), b.Interface().(time.Time) //nolint:forcetypeassert
+			switch {
+			case at.Before(bt):
+				return -1
+			case at.After(bt):
+				return 1
+			}
+			return 0
+		}
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+	return 0
+}
+
+// compareFloats compares floats, NaN is considered smaller than other numbers
+func compareFloats(a, b float64) int {
+	aNaN, bNaN := a != a, b != b //nolint:gocritic
+	if aNaN || bNaN {
+		return compareBools(!aNaN, !bNaN)
+	}
+	return compareOrdered(a, b)
+}
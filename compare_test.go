@@ -0,0 +1,60 @@
+package rflutil
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_orderedValue_compare(t *testing.T) {
+	cmp := func(a, b any) int {
+		va, err := toOrderedValue(valOf(a))
+		assert.Nil(t, err)
+		vb, err := toOrderedValue(valOf(b))
+		assert.Nil(t, err)
+		return va.compare(&vb)
+	}
+
+	assert.Equal(t, 0, cmp(nil, nil))
+	assert.Equal(t, -1, cmp(nil, 0))
+	assert.Equal(t, -1, cmp(false, true))
+	assert.Equal(t, 1, cmp(int8(2), int8(-1)))
+	assert.Equal(t, 0, cmp(uint(2), uint(2)))
+	assert.Equal(t, -1, cmp(1.5, 2.5))
+	assert.Equal(t, -1, cmp(math.NaN(), -1.0))
+	assert.Equal(t, 0, cmp(math.NaN(), math.NaN()))
+	assert.Equal(t, 1, cmp("b", "a"))
+	assert.Equal(t, 1, cmp(ptrOf("b"), "a"))
+	t0 := time.Now()
+	assert.Equal(t, -1, cmp(t0, t0.Add(time.Second)))
+	assert.Equal(t, 0, cmp(t0, t0))
+
+	_, err := toOrderedValue(valOf([]int{}))
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+}
+
+func Test_compareValues(t *testing.T) {
+	cmp := func(a, b any) int {
+		return compareValues(valOf(a), valOf(b))
+	}
+
+	assert.Equal(t, 0, cmp(nil, nil))
+	assert.Equal(t, -1, cmp(nil, false))
+	assert.Equal(t, 1, cmp(1, true))
+	assert.Equal(t, 1, cmp(int64(1), 2))
+	assert.Equal(t, -1, cmp(math.NaN(), math.Inf(-1)))
+	assert.Equal(t, -1, cmp(complex(1, 2), complex(1, 3)))
+	assert.Equal(t, 1, cmp(complex(2, 0), complex(1, 3)))
+	t0 := time.Now()
+	assert.Equal(t, 1, cmp(t0.Add(time.Second), t0))
+	assert.Equal(t, 0, cmp([]any{nil}[0], nil))
+
+	var i1, i2 any = 1, nil
+	assert.Equal(t, 1, compareValues(valOf(&i1).Elem(), valOf(&i2).Elem()))
+	assert.Equal(t, 0, compareValues(valOf(&i1).Elem(), valOf(&i1).Elem()))
+
+	p := ptrOf(1)
+	assert.Equal(t, 0, cmp(p, p))
+}
@@ -0,0 +1,321 @@
+package rflutil
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dumpDefaultIndent    = "  "
+	dumpDefaultRedactTag = "dump"
+)
+
+// DumpOptions options of Dump
+type DumpOptions struct {
+	// MaxDepth max depth of nested values to print, the deeper ones are printed as <max depth>.
+	// 0 means unlimited.
+	MaxDepth int
+	// MaxLength max number of items of slices, arrays, and maps, and max number of bytes of strings
+	// to print. 0 means unlimited.
+	MaxLength int
+	// Indent indentation of nested values, default is 2 spaces
+	Indent string
+	// ShowAddresses prints addresses of pointers and maps instead of IDs such as #1, #2
+	ShowAddresses bool
+	// HideTypes omits type annotations
+	HideTypes bool
+	// RedactTag tag key to redact struct fields, default is `dump`. Fields having tag value `redact`
+	// (or the attribute `redact`) are printed as <redacted>, fields having tag value `-` are omitted.
+	RedactTag string
+}
+
+// Dump prints a value as an indented tree with types, for debugging, for example:
+//
+//	*rflutil.User (#1) {
+//	  Name: string "abc"
+//	  Tags: []string (len=1 cap=1) [
+//	    0: string "a"
+//	  ]
+//	  Password: string <redacted>
+//	  Parent: *rflutil.User (#1) <cycle>
+//	}
+//
+// Unexported struct fields are printed too. Map entries are printed in natural order of keys.
+// Returns the error of the writer, or *TagSyntaxError if a field has an invalid redact tag.
+func Dump(w io.Writer, v reflect.Value, opts DumpOptions) error {
+	if opts.Indent == "" {
+		opts.Indent = dumpDefaultIndent
+	}
+	if opts.RedactTag == "" {
+		opts.RedactTag = dumpDefaultRedactTag
+	}
+	d := &dumper{
+		w:      w,
+		opts:   &opts,
+		ids:    map[visitedPtr]int{},
+		onPath: map[visitedPtr]struct{}{},
+	}
+	d.dump(v, 0, true)
+	d.write("\n")
+	return d.err
+}
+
+// Sdump is the same as Dump, but it returns the result as a string
+func Sdump(v reflect.Value, opts DumpOptions) string {
+	var sb strings.Builder
+	_ = Dump(&sb, v, opts)
+	return sb.String()
+}
+
+type dumper struct {
+	w      io.Writer
+	opts   *DumpOptions
+	err    error
+	ids    map[visitedPtr]int
+	onPath map[visitedPtr]struct{} // pointers, maps, and slices being printed, to detect cycles
+}
+
+func (d *dumper) write(s string) {
+	if d.err == nil {
+		_, d.err = io.WriteString(d.w, s)
+	}
+}
+
+func (d *dumper) writeIndent(depth int) {
+	d.write(strings.Repeat(d.opts.Indent, depth))
+}
+
+func (d *dumper) writeType(v reflect.Value, showType bool) {
+	if showType && !d.opts.HideTypes {
+		d.write(v.Type().String())
+		d.write(" ")
+	}
+}
+
+// ref returns the address or the ID of a pointer
+func (d *dumper) ref(key visitedPtr) string {
+	if d.opts.ShowAddresses {
+		return "0x" + strconv.FormatUint(uint64(key.ptr), 16) //nolint:mnd
+	}
+	id, ok := d.ids[key]
+	if !ok {
+		id = len(d.ids) + 1
+		d.ids[key] = id
+	}
+	return "#" + strconv.Itoa(id)
+}
+
+func (d *dumper) isDepthExceeded(depth int) bool {
+	return d.opts.MaxDepth > 0 && depth >= d.opts.MaxDepth
+}
+
+//nolint:gocyclo
+func (d *dumper) dump(v reflect.Value, depth int, showType bool) {
+	if !v.IsValid() {
+		d.write("<invalid>")
+		return
+	}
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			d.writeType(v, showType)
+			d.write("nil")
+			return
+		}
+		d.dump(v.Elem(), depth, showType)
+		return
+	}
+	d.writeType(v, showType)
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if v.IsNil() {
+			d.write("nil")
+			return
+		}
+		key := visitedPtr{ptr: v.Pointer(), typ: v.Type()}
+		d.write("(" + d.ref(key) + ") ")
+		if _, ok := d.onPath[key]; ok {
+			d.write("<cycle>")
+			return
+		}
+		d.onPath[key] = struct{}{}
+		d.dump(v.Elem(), depth, false)
+		delete(d.onPath, key)
+	case reflect.Struct:
+		d.dumpStruct(v, depth)
+	case reflect.Slice, reflect.Array:
+		d.dumpSlice(v, depth)
+	case reflect.Map:
+		d.dumpMap(v, depth)
+	case reflect.String:
+		d.dumpString(v.String())
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if v.IsNil() {
+			d.write("nil")
+			return
+		}
+		d.write("(" + d.ref(visitedPtr{ptr: v.Pointer(), typ: v.Type()}) + ")")
+	default:
+		d.write(fmt.Sprintf("%v", v))
+	}
+}
+
+func (d *dumper) dumpString(s string) {
+	if d.opts.MaxLength > 0 && len(s) > d.opts.MaxLength {
+		d.write(strconv.Quote(s[:d.opts.MaxLength]))
+		d.write(fmt.Sprintf("... (len=%d)", len(s)))
+		return
+	}
+	d.write(strconv.Quote(s))
+}
+
+func (d *dumper) dumpStruct(v reflect.Value, depth int) {
+	typ := v.Type()
+	if typ == timeType && v.CanInterface() {
+		d.write(v.Interface().(time.Time).Format(time.RFC3339Nano)) //nolint:forcetypeassert
+		return
+	}
+	if typ.NumField() == 0 {
+		d.write("{}")
+		return
+	}
+	if d.isDepthExceeded(depth) {
+		d.write("{<max depth>}")
+		return
+	}
+	// Unexported fields are only accessible via an addressable struct
+	if !v.CanAddr() {
+		addressable := reflect.New(typ).Elem()
+		addressable.Set(v)
+		v = addressable
+	}
+
+	d.write("{\n")
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		tag, err := ParseTag(&sf, d.opts.RedactTag, ",")
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			if d.err == nil {
+				d.err = err
+			}
+			return
+		}
+		if tag != nil && tag.Ignored {
+			continue
+		}
+		d.writeIndent(depth + 1)
+		d.write(sf.Name + ": ")
+		field := makeAccessible(v.Field(i))
+		if tag != nil && (tag.Name == "redact" || tag.HasAttr("redact")) {
+			d.writeType(field, true)
+			d.write("<redacted>\n")
+			continue
+		}
+		d.dump(field, depth+1, true)
+		d.write("\n")
+	}
+	d.writeIndent(depth)
+	d.write("}")
+}
+
+func (d *dumper) dumpSlice(v reflect.Value, depth int) {
+	if v.Kind() == reflect.Slice {
+		if v.IsNil() {
+			d.write("nil")
+			return
+		}
+		d.write(fmt.Sprintf("(len=%d cap=%d) ", v.Len(), v.Cap()))
+	} else {
+		d.write(fmt.Sprintf("(len=%d) ", v.Len()))
+	}
+	if v.Len() == 0 {
+		d.write("[]")
+		return
+	}
+	if v.Kind() == reflect.Slice {
+		key := visitedPtr{ptr: v.Pointer(), typ: v.Type()}
+		if _, ok := d.onPath[key]; ok {
+			d.write("<cycle>")
+			return
+		}
+		d.onPath[key] = struct{}{}
+		defer delete(d.onPath, key)
+	}
+	if d.isDepthExceeded(depth) {
+		d.write("[<max depth>]")
+		return
+	}
+
+	d.write("[\n")
+	n := d.limitLength(v.Len())
+	for i := 0; i < n; i++ {
+		d.writeIndent(depth + 1)
+		d.write(strconv.Itoa(i) + ": ")
+		d.dump(v.Index(i), depth+1, true)
+		d.write("\n")
+	}
+	d.writeMore(v.Len()-n, depth)
+	d.writeIndent(depth)
+	d.write("]")
+}
+
+func (d *dumper) dumpMap(v reflect.Value, depth int) {
+	if v.IsNil() {
+		d.write("nil")
+		return
+	}
+	key := visitedPtr{ptr: v.Pointer(), typ: v.Type()}
+	d.write(fmt.Sprintf("(len=%d) ", v.Len()))
+	if _, ok := d.onPath[key]; ok {
+		d.write("<cycle>")
+		return
+	}
+	if v.Len() == 0 {
+		d.write("{}")
+		return
+	}
+	if d.isDepthExceeded(depth) {
+		d.write("{<max depth>}")
+		return
+	}
+
+	d.onPath[key] = struct{}{}
+	defer delete(d.onPath, key)
+
+	d.write("{\n")
+	keys, _ := MapKeysSorted(v)
+	n := d.limitLength(len(keys))
+	for _, k := range keys[:n] {
+		d.writeIndent(depth + 1)
+		if k.Kind() == reflect.String {
+			d.write(strconv.Quote(k.String()))
+		} else {
+			d.write(fmt.Sprintf("%v", k))
+		}
+		d.write(": ")
+		d.dump(v.MapIndex(k), depth+1, true)
+		d.write("\n")
+	}
+	d.writeMore(len(keys)-n, depth)
+	d.writeIndent(depth)
+	d.write("}")
+}
+
+func (d *dumper) limitLength(n int) int {
+	if d.opts.MaxLength > 0 && n > d.opts.MaxLength {
+		return d.opts.MaxLength
+	}
+	return n
+}
+
+func (d *dumper) writeMore(remaining, depth int) {
+	if remaining > 0 {
+		d.writeIndent(depth + 1)
+		d.write(fmt.Sprintf("... (%d more)\n", remaining))
+	}
+}
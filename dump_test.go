@@ -0,0 +1,193 @@
+package rflutil
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dumpTestUser struct {
+	Name     string
+	Age      int
+	Tags     []string
+	Attrs    map[string]any
+	Password string `dump:"redact"`
+	Token    string `log:"token,redact"`
+	Internal string `dump:"-"`
+	Parent   *dumpTestUser
+	Created  time.Time
+	secret   int
+}
+
+func Test_Dump(t *testing.T) {
+	t.Run("#1: struct with cycle and redaction", func(t *testing.T) {
+		u := &dumpTestUser{
+			Name:     "abc",
+			Age:      30,
+			Tags:     []string{"a"},
+			Attrs:    map[string]any{"b": nil, "a": 1.5},
+			Password: "xxx",
+			Token:    "yyy",
+			Internal: "zzz",
+			Created:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			secret:   7,
+		}
+		u.Parent = u
+		assert.Equal(t, strings.Join([]string{
+			`*rflutil.dumpTestUser (#1) {`,
+			`  Name: string "abc"`,
+			`  Age: int 30`,
+			`  Tags: []string (len=1 cap=1) [`,
+			`    0: string "a"`,
+			`  ]`,
+			`  Attrs: map[string]interface {} (len=2) {`,
+			`    "a": float64 1.5`,
+			`    "b": interface {} nil`,
+			`  }`,
+			`  Password: string <redacted>`,
+			`  Token: string "yyy"`,
+			`  Parent: *rflutil.dumpTestUser (#1) <cycle>`,
+			`  Created: time.Time 2024-01-02T03:04:05Z`,
+			`  secret: int 7`,
+			`}`,
+			``,
+		}, "\n"), Sdump(valOf(u), DumpOptions{}))
+	})
+
+	t.Run("#2: custom redact tag, hidden types, indentation", func(t *testing.T) {
+		u := dumpTestUser{Token: "yyy", Tags: []string{}}
+		assert.Equal(t, strings.Join([]string{
+			`{`,
+			`    Name: ""`,
+			`    Age: 0`,
+			`    Tags: (len=0 cap=0) []`,
+			`    Attrs: nil`,
+			`    Password: ""`,
+			`    Token: <redacted>`,
+			`    Internal: ""`,
+			`    Parent: nil`,
+			`    Created: 0001-01-01T00:00:00Z`,
+			`    secret: 0`,
+			`}`,
+			``,
+		}, "\n"), Sdump(valOf(u), DumpOptions{RedactTag: "log", HideTypes: true, Indent: "    "}))
+	})
+
+	t.Run("#3: max depth and max length", func(t *testing.T) {
+		v := map[string]any{
+			"list":   []int{1, 2, 3, 4},
+			"nested": map[string]any{"x": []int{1}},
+			"s":      "abcdef",
+		}
+		assert.Equal(t, strings.Join([]string{
+			`map[string]interface {} (len=3) {`,
+			`  "list": []int (len=4 cap=4) [`,
+			`    0: int 1`,
+			`    1: int 2`,
+			`    ... (2 more)`,
+			`  ]`,
+			`  "nested": map[string]interface {} (len=1) {`,
+			`    "x": []int (len=1 cap=1) [<max depth>]`,
+			`  }`,
+			`  ... (1 more)`,
+			`}`,
+			``,
+		}, "\n"), Sdump(valOf(v), DumpOptions{MaxDepth: 2, MaxLength: 2}))
+
+		assert.Equal(t, "string \"abc\"... (len=6)\n", Sdump(valOf("abcdef"), DumpOptions{MaxLength: 3}))
+	})
+
+	t.Run("#4: shared pointers are not cycles", func(t *testing.T) {
+		n := 1
+		v := [2]*int{&n, &n}
+		assert.Equal(t, strings.Join([]string{
+			`[2]*int (len=2) [`,
+			`  0: *int (#1) 1`,
+			`  1: *int (#1) 1`,
+			`]`,
+			``,
+		}, "\n"), Sdump(valOf(v), DumpOptions{}))
+	})
+
+	t.Run("#5: map cycle and addresses", func(t *testing.T) {
+		m := map[string]any{}
+		m["self"] = m
+		s := Sdump(valOf(m), DumpOptions{})
+		assert.Equal(t, strings.Join([]string{
+			`map[string]interface {} (len=1) {`,
+			`  "self": map[string]interface {} (len=1) <cycle>`,
+			`}`,
+			``,
+		}, "\n"), s)
+
+		n := 1
+		s = Sdump(valOf(&n), DumpOptions{ShowAddresses: true})
+		assert.True(t, strings.HasPrefix(s, "*int (0x"))
+	})
+
+	t.Run("#6: slice cycle", func(t *testing.T) {
+		s := []any{1, nil}
+		s[1] = s
+		assert.Equal(t, strings.Join([]string{
+			`[]interface {} (len=2 cap=2) [`,
+			`  0: int 1`,
+			`  1: []interface {} (len=2 cap=2) <cycle>`,
+			`]`,
+			``,
+		}, "\n"), Sdump(valOf(s), DumpOptions{}))
+
+		// The same slice printed twice side by side is not a cycle
+		inner := []int{1}
+		assert.Equal(t, strings.Join([]string{
+			`[2][]int (len=2) [`,
+			`  0: []int (len=1 cap=1) [`,
+			`    0: int 1`,
+			`  ]`,
+			`  1: []int (len=1 cap=1) [`,
+			`    0: int 1`,
+			`  ]`,
+			`]`,
+			``,
+		}, "\n"), Sdump(valOf([2][]int{inner, inner}), DumpOptions{}))
+	})
+
+	t.Run("#7: other kinds", func(t *testing.T) {
+		var fn func()
+		var iface error
+		assert.Equal(t, "func() nil\n", Sdump(valOf(fn), DumpOptions{}))
+		assert.Equal(t, "<invalid>\n", Sdump(valOf(iface), DumpOptions{}))
+		assert.Equal(t, "struct {} {}\n", Sdump(valOf(struct{}{}), DumpOptions{}))
+		assert.Equal(t, "map[int]bool (len=1) {\n  1: bool true\n}\n", Sdump(valOf(map[int]bool{1: true}), DumpOptions{}))
+		assert.Equal(t, "*errors.errorString (#1) {\n  s: string \"x\"\n}\n",
+			Sdump(valOf(errors.New("x")), DumpOptions{}))
+	})
+}
+
+type dumpTestFailWriter struct{}
+
+func (dumpTestFailWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func Test_Dump_failure(t *testing.T) {
+	t.Run("#1: write failed", func(t *testing.T) {
+		err := Dump(dumpTestFailWriter{}, reflect.ValueOf(1), DumpOptions{})
+		assert.ErrorContains(t, err, "write failed")
+	})
+
+	t.Run("#2: invalid tag syntax", func(t *testing.T) {
+		type Item struct {
+			A int
+			B int `dump:"redact,x='1'y"`
+		}
+		var sb strings.Builder
+		err := Dump(&sb, valOf(Item{}), DumpOptions{})
+		assert.ErrorIs(t, err, ErrTagSyntax)
+		var syntaxErr *TagSyntaxError
+		assert.ErrorAs(t, err, &syntaxErr)
+	})
+}
@@ -9,4 +9,8 @@ var (
 	ErrValueUnaddressable = errors.New("ErrValueUnaddressable")
 	ErrValueUnsettable    = errors.New("ErrValueUnsettable")
 	ErrIndexOutOfRange    = errors.New("ErrIndexOutOfRange")
+	ErrValueInvalid       = errors.New("ErrValueInvalid")
+	ErrTagSyntax          = errors.New("ErrTagSyntax")
+	ErrDuplicated         = errors.New("ErrDuplicated")
+	ErrPanicRecovered     = errors.New("ErrPanicRecovered")
 )
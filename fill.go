@@ -0,0 +1,410 @@
+package rflutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+const (
+	fillDefaultTag         = "fill"
+	fillDefaultValidateTag = "validate"
+	fillDefaultMaxLen      = 8
+	fillDefaultMaxDepth    = 4
+	fillDefaultFloatRange  = 1e6
+	fillMapKeyAttempts     = 4          // number of attempts per map entry to generate unique keys
+	fillTimeRange          = 4102444800 // seconds from 1970 to 2100
+	fillStringChars        = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	uint64Bits             = 64
+)
+
+// FillGenerator generates a random value, the value is converted to the target type as ValueAs does
+type FillGenerator func(rng *rand.Rand) any
+
+// FillOptions options of Fill
+type FillOptions struct {
+	// MinLen, MaxLen length range of strings, slices, and maps, default is [0, 8]
+	MinLen int
+	MaxLen int
+	// NilProbability probability of nil pointers, slices, and maps, in range [0, 1], default is 0
+	NilProbability float64
+	// MaxDepth max depth of nested pointers, slices, arrays, and maps, default is 4.
+	// The deeper values are left nil, that stops the generation of recursive types.
+	// Required values deeper than this result in ErrValueInvalid.
+	MaxDepth int
+	// Tag tag key to set generators of fields, default is `fill`. For example, `fill:"email"` uses
+	// the generator named `email` in NamedGenerators, `fill:"-"` skips the field.
+	// Attributes of the tag are constraints too, e.g. `fill:",min=1,max=9"`.
+	Tag string
+	// ValidateTag tag key of constraints, default is `validate`, e.g. `validate:"min=1,max=9"`
+	ValidateTag string
+	// Generators generators of the values of the types
+	Generators map[reflect.Type]FillGenerator
+	// NamedGenerators generators used by fields via tags
+	NamedGenerators map[string]FillGenerator
+}
+
+// Fill populates a value with random data, nested structs, slices, arrays, maps, and pointers are
+// populated recursively. Input v must be a pointer or a settable value. When rng is nil, a random
+// generator seeded with the current time is used, pass a seeded one to reproduce the data.
+//
+// Struct fields respect the constraints in the tags:
+//   - `min`, `max`: range of numbers, or range of lengths of strings, slices, and maps
+//   - `enum=[a,b]` or `oneof=a b`: the allowed values
+//   - `required`: the field is never nil
+//
+// Unexported fields are skipped. Interfaces, funcs, and chans are left nil unless they have generators.
+func Fill(v reflect.Value, rng *rand.Rand, opts FillOptions) error {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+	}
+	if opts.MaxLen == 0 {
+		opts.MaxLen = fillDefaultMaxLen
+	}
+	if opts.MaxDepth == 0 {
+		opts.MaxDepth = fillDefaultMaxDepth
+	}
+	if opts.Tag == "" {
+		opts.Tag = fillDefaultTag
+	}
+	if opts.ValidateTag == "" {
+		opts.ValidateTag = fillDefaultValidateTag
+	}
+	if opts.MinLen < 0 || opts.MinLen > opts.MaxLen {
+		return fmt.Errorf("%w: invalid length range [%d, %d]", ErrValueInvalid, opts.MinLen, opts.MaxLen)
+	}
+
+	if !v.IsValid() {
+		return fmt.Errorf("%w: value is invalid", ErrValueInvalid)
+	}
+	target := v
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return fmt.Errorf("%w: value is a nil pointer", ErrValueInvalid)
+		}
+		target = v.Elem()
+	}
+	if !target.CanSet() {
+		return fmt.Errorf("%w: require pointer (got %v)", ErrValueUnsettable, v.Type())
+	}
+
+	f := &filler{rng: rng, opts: &opts}
+	return f.fill(target, 0, nil, "")
+}
+
+type filler struct {
+	rng  *rand.Rand
+	opts *FillOptions
+}
+
+//nolint:gocyclo
+func (f *filler) fill(v reflect.Value, depth int, constraints *Tag, path string) error {
+	if gen, ok := f.opts.Generators[v.Type()]; ok {
+		return f.setGenerated(v, gen, path)
+	}
+	if isKindIn(v.Kind(), reflect.Pointer, reflect.Slice, reflect.Map) {
+		isNil, err := f.isNil(depth, constraints, path)
+		if err != nil || isNil {
+			v.Set(reflect.Zero(v.Type()))
+			return err
+		}
+	}
+	if constraints != nil && isKindIn(v.Kind(), reflect.Bool, reflect.String, reflect.Int, reflect.Int8,
+		reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64) {
+		enum, err := validationEnum(constraints, v.Kind())
+		if err != nil {
+			return fmt.Errorf("%w (path '%s')", err, path)
+		}
+		if len(enum) > 0 {
+			return f.setGenerated(v, func(rng *rand.Rand) any { return enum[rng.Intn(len(enum))] }, path)
+		}
+	}
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		v.SetBool(f.rng.Int63()&1 == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		if err := f.fillNumber(v, constraints); err != nil {
+			return fmt.Errorf("%w (path '%s')", err, path)
+		}
+	case reflect.String:
+		n, err := f.length(constraints)
+		if err != nil {
+			return fmt.Errorf("%w (path '%s')", err, path)
+		}
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = fillStringChars[f.rng.Intn(len(fillStringChars))]
+		}
+		v.SetString(string(b))
+	case reflect.Pointer:
+		ptr := reflect.New(v.Type().Elem())
+		if err := f.fill(ptr.Elem(), depth+1, constraints, path); err != nil {
+			return err
+		}
+		v.Set(ptr)
+	case reflect.Slice, reflect.Array:
+		return f.fillSlice(v, depth, constraints, path)
+	case reflect.Map:
+		return f.fillMap(v, depth, constraints, path)
+	case reflect.Struct:
+		if v.Type() == timeType {
+			t := time.Unix(f.rng.Int63n(fillTimeRange), f.rng.Int63n(int64(time.Second))).UTC()
+			v.Set(reflect.ValueOf(t))
+			return nil
+		}
+		return f.fillStruct(v, depth, path)
+	}
+	return nil
+}
+
+// isNil decides whether a nillable value is left nil. Required values are never nil,
+// an error is returned if they are deeper than the max depth.
+func (f *filler) isNil(depth int, constraints *Tag, path string) (bool, error) {
+	required := constraints != nil && constraints.HasAttr("required")
+	if depth >= f.opts.MaxDepth {
+		if required {
+			return false, fmt.Errorf("%w: required value exceeds max depth %d (path '%s')",
+				ErrValueInvalid, f.opts.MaxDepth, path)
+		}
+		return true, nil
+	}
+	if required {
+		return false, nil
+	}
+	return f.opts.NilProbability > 0 && f.rng.Float64() < f.opts.NilProbability, nil
+}
+
+func (f *filler) setGenerated(v reflect.Value, gen FillGenerator, path string) error {
+	converted, err := valueConvert(reflect.ValueOf(gen(f.rng)), v.Type(), false)
+	if err != nil {
+		return fmt.Errorf("%w (path '%s')", err, path)
+	}
+	v.Set(converted)
+	return nil
+}
+
+func (f *filler) fillNumber(v reflect.Value, constraints *Tag) error {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		typeHi := int64(^uint64(0) >> (uint64Bits - v.Type().Bits() + 1))
+		lo, hi, err := intRangeOf(constraints, -typeHi-1, typeHi)
+		if err != nil {
+			return err
+		}
+		if lo < -typeHi-1 || hi > typeHi {
+			return fmt.Errorf("%w: range [%d, %d] overflows %v", ErrValueInvalid, lo, hi, v.Type())
+		}
+		v.SetInt(lo + int64(f.randUint64(uint64(hi-lo))))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		typeHi := ^uint64(0) >> (uint64Bits - v.Type().Bits())
+		defHi := int64(math.MaxInt64)
+		if typeHi < math.MaxInt64 {
+			defHi = int64(typeHi)
+		}
+		lo, hi, err := intRangeOf(constraints, 0, defHi)
+		if err != nil {
+			return err
+		}
+		if lo < 0 || uint64(hi) > typeHi {
+			return fmt.Errorf("%w: range [%d, %d] overflows %v", ErrValueInvalid, lo, hi, v.Type())
+		}
+		upper := uint64(hi)
+		if constraints == nil || !constraints.HasAttr("max") {
+			upper = typeHi // the default upper bound may exceed int64
+		}
+		v.SetUint(uint64(lo) + f.randUint64(upper-uint64(lo)))
+	case reflect.Float32, reflect.Float64:
+		lo, hi, err := floatRangeOf(constraints)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(lo + f.rng.Float64()*(hi-lo))
+	case reflect.Complex64, reflect.Complex128:
+		lo, hi, err := floatRangeOf(constraints)
+		if err != nil {
+			return err
+		}
+		v.SetComplex(complex(lo+f.rng.Float64()*(hi-lo), lo+f.rng.Float64()*(hi-lo)))
+	}
+	return nil
+}
+
+// randUint64 returns a random number in range [0, n]
+func (f *filler) randUint64(n uint64) uint64 {
+	if n == ^uint64(0) {
+		return f.rng.Uint64()
+	}
+	return f.rng.Uint64() % (n + 1)
+}
+
+// length returns a random length within the constraints
+func (f *filler) length(constraints *Tag) (int, error) {
+	lo, hi, err := intRangeOf(constraints, int64(f.opts.MinLen), int64(f.opts.MaxLen))
+	if err != nil {
+		return 0, err
+	}
+	if lo < 0 {
+		return 0, fmt.Errorf("%w: negative length %d", ErrValueInvalid, lo)
+	}
+	return int(lo + f.rng.Int63n(hi-lo+1)), nil
+}
+
+func (f *filler) fillSlice(v reflect.Value, depth int, constraints *Tag, path string) error {
+	result := v
+	if v.Kind() == reflect.Slice {
+		n, err := f.length(constraints)
+		if err != nil {
+			return fmt.Errorf("%w (path '%s')", err, path)
+		}
+		result = reflect.MakeSlice(v.Type(), n, n)
+	}
+	for i := 0; i < result.Len(); i++ {
+		if err := f.fill(result.Index(i), depth+1, nil, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	v.Set(result)
+	return nil
+}
+
+func (f *filler) fillMap(v reflect.Value, depth int, constraints *Tag, path string) error {
+	n, err := f.length(constraints)
+	if err != nil {
+		return fmt.Errorf("%w (path '%s')", err, path)
+	}
+	typ := v.Type()
+	result := reflect.MakeMapWithSize(typ, n)
+	for attempts := 0; result.Len() < n && attempts < n*fillMapKeyAttempts; attempts++ {
+		key := reflect.New(typ.Key()).Elem()
+		if err = f.fill(key, depth+1, nil, path+"[]"); err != nil {
+			return err
+		}
+		if result.MapIndex(key).IsValid() {
+			continue
+		}
+		item := reflect.New(typ.Elem()).Elem()
+		if err = f.fill(item, depth+1, nil, fmt.Sprintf("%s[%v]", path, key)); err != nil {
+			return err
+		}
+		result.SetMapIndex(key, item)
+	}
+	v.Set(result)
+	return nil
+}
+
+func (f *filler) fillStruct(v reflect.Value, depth int, path string) error {
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		field := v.Field(i)
+		fieldPath := joinFieldPath(path, sf.Name)
+		if !sf.IsExported() {
+			// Exported fields of unexported embedded structs are settable
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				if err := f.fillStruct(field, depth, fieldPath); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		fillTag, err := ParseTag(&sf, f.opts.Tag, ",")
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if fillTag != nil && fillTag.Ignored {
+			continue
+		}
+		constraints, err := parseAttrTag(&sf, f.opts.ValidateTag, ",")
+		if err != nil {
+			return err
+		}
+		if fillTag != nil {
+			if constraints == nil {
+				constraints = &Tag{FieldName: sf.Name, Source: f.opts.Tag, Attrs: map[string]string{}}
+			}
+			mapExtend(constraints.Attrs, fillTag.Attrs, false)
+		}
+
+		if fillTag != nil && fillTag.Name != "" {
+			gen, ok := f.opts.NamedGenerators[fillTag.Name]
+			if !ok {
+				return fmt.Errorf("%w: generator '%s' (path '%s')", ErrNotFound, fillTag.Name, fieldPath)
+			}
+			if err = f.setGenerated(field, gen, fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = f.fill(field, depth, constraints, fieldPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// intRangeOf returns the range set by the attributes `min` and `max`, or the default range.
+// A default bound is moved to the other bound when it's out of the range set by the attributes.
+func intRangeOf(constraints *Tag, defLo, defHi int64) (lo, hi int64, err error) {
+	lo, hi = defLo, defHi
+	hasMin := constraints != nil && constraints.HasAttr("min")
+	hasMax := constraints != nil && constraints.HasAttr("max")
+	if hasMin {
+		n, err := constraints.GetAttrInt("min")
+		if err != nil {
+			return 0, 0, err
+		}
+		lo = int64(n)
+	}
+	if hasMax {
+		n, err := constraints.GetAttrInt("max")
+		if err != nil {
+			return 0, 0, err
+		}
+		hi = int64(n)
+	}
+	switch {
+	case lo <= hi:
+	case hasMin && !hasMax:
+		hi = lo
+	case hasMax && !hasMin:
+		lo = hi
+	default:
+		return 0, 0, fmt.Errorf("%w: invalid range [%d, %d]", ErrValueInvalid, lo, hi)
+	}
+	return lo, hi, nil
+}
+
+// floatRangeOf is the same as intRangeOf, but for floats
+func floatRangeOf(constraints *Tag) (lo, hi float64, err error) {
+	lo, hi = -fillDefaultFloatRange, fillDefaultFloatRange
+	hasMin := constraints != nil && constraints.HasAttr("min")
+	hasMax := constraints != nil && constraints.HasAttr("max")
+	if hasMin {
+		if lo, err = constraints.GetAttrFloat("min"); err != nil {
+			return 0, 0, err
+		}
+	}
+	if hasMax {
+		if hi, err = constraints.GetAttrFloat("max"); err != nil {
+			return 0, 0, err
+		}
+	}
+	switch {
+	case lo <= hi:
+	case hasMin && !hasMax:
+		hi = lo
+	case hasMax && !hasMin:
+		lo = hi
+	default:
+		return 0, 0, fmt.Errorf("%w: invalid range [%v, %v]", ErrValueInvalid, lo, hi)
+	}
+	return lo, hi, nil
+}
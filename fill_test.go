@@ -0,0 +1,197 @@
+package rflutil
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Fill(t *testing.T) {
+	type Address struct {
+		City string `validate:"min=3,max=3"`
+		Zip  uint16 `validate:"min=10000,max=10100"`
+	}
+	type embedded struct {
+		Embedded int8
+	}
+	type User struct {
+		embedded
+		Name     string  `validate:"required,min=1,max=5"`
+		Age      int     `validate:"min=18,max=60"`
+		Score    float64 `fill:",min=0.5,max=1"`
+		Role     string  `validate:"oneof=admin user"`
+		Level    uint8   `fill:",enum=[1,2,3]"`
+		Active   bool
+		Email    string `fill:"email"`
+		Address  *Address
+		Tags     []string       `validate:"min=2,max=4"`
+		Attrs    map[string]int `validate:"min=1"`
+		Pair     [2]int32
+		Created  time.Time
+		Duration time.Duration
+		Skipped  string `fill:"-"`
+		Any      any
+		Fn       func()
+		Nested   map[string][]bool
+		internal int //nolint:unused
+	}
+	opts := FillOptions{
+		NamedGenerators: map[string]FillGenerator{
+			"email": func(rng *rand.Rand) any { return "user" + string(rune('a'+rng.Intn(26))) + "@example.com" },
+		},
+		Generators: map[reflect.Type]FillGenerator{
+			reflect.TypeOf(time.Duration(0)): func(rng *rand.Rand) any { return rng.Intn(10) * int(time.Second) },
+		},
+	}
+
+	for i := 0; i < 50; i++ {
+		var u User
+		assert.Nil(t, Fill(valOf(&u), rand.New(rand.NewSource(int64(i))), opts)) //nolint:gosec
+
+		assert.True(t, len(u.Name) >= 1 && len(u.Name) <= 5)
+		assert.True(t, u.Age >= 18 && u.Age <= 60)
+		assert.True(t, u.Score >= 0.5 && u.Score <= 1)
+		assert.Contains(t, []string{"admin", "user"}, u.Role)
+		assert.Contains(t, []uint8{1, 2, 3}, u.Level)
+		assert.True(t, strings.HasSuffix(u.Email, "@example.com"))
+		assert.NotNil(t, u.Address)
+		assert.Len(t, u.Address.City, 3)
+		assert.True(t, u.Address.Zip >= 10000 && u.Address.Zip <= 10100)
+		assert.True(t, len(u.Tags) >= 2 && len(u.Tags) <= 4)
+		assert.True(t, len(u.Attrs) >= 1 && len(u.Attrs) <= 8)
+		assert.True(t, u.Created.Year() >= 1970 && u.Created.Year() < 2100)
+		assert.Equal(t, time.Duration(0), u.Duration%time.Second)
+		assert.Equal(t, "", u.Skipped)
+		assert.Nil(t, u.Any)
+		assert.Nil(t, u.Fn)
+		assert.Equal(t, 0, u.internal)
+	}
+
+	t.Run("#1: reproducible with the same seed", func(t *testing.T) {
+		var u1, u2 User
+		assert.Nil(t, Fill(valOf(&u1), rand.New(rand.NewSource(1)), opts)) //nolint:gosec
+		assert.Nil(t, Fill(valOf(&u2), rand.New(rand.NewSource(1)), opts)) //nolint:gosec
+		assert.Equal(t, u1, u2)
+	})
+
+	t.Run("#2: embedded fields", func(t *testing.T) {
+		found := false
+		for i := 0; i < 10 && !found; i++ {
+			var u User
+			assert.Nil(t, Fill(valOf(&u), nil, opts))
+			found = u.Embedded != 0
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("#3: nil probability and required fields", func(t *testing.T) {
+		type Item struct {
+			A *int
+			B []int
+			C map[int]int
+			D *int `validate:"required"`
+		}
+		var item Item
+		assert.Nil(t, Fill(valOf(&item), rand.New(rand.NewSource(1)), FillOptions{NilProbability: 1})) //nolint:gosec
+		assert.Nil(t, item.A)
+		assert.Nil(t, item.B)
+		assert.Nil(t, item.C)
+		assert.NotNil(t, item.D)
+	})
+
+	t.Run("#4: lengths and recursive types", func(t *testing.T) {
+		type Node struct {
+			Children []*Node
+			Name     string
+		}
+		var n Node
+		assert.Nil(t, Fill(valOf(&n), rand.New(rand.NewSource(1)), //nolint:gosec
+			FillOptions{MinLen: 2, MaxLen: 2, MaxDepth: 3}))
+		assert.Len(t, n.Name, 2)
+		assert.Len(t, n.Children, 2)
+		assert.Len(t, n.Children[0].Children, 2)
+		assert.Nil(t, n.Children[0].Children[0]) // depth 3
+	})
+
+	t.Run("#5: non-struct values", func(t *testing.T) {
+		var s []uint64
+		assert.Nil(t, Fill(valOf(&s), nil, FillOptions{MinLen: 3, MaxLen: 3}))
+		assert.Len(t, s, 3)
+
+		var m map[int8]*string
+		assert.Nil(t, Fill(valOf(&m), nil, FillOptions{MinLen: 5, MaxLen: 5}))
+		assert.Len(t, m, 5)
+		for _, v := range m {
+			assert.NotNil(t, v)
+		}
+	})
+}
+
+func Test_Fill_failure(t *testing.T) {
+	t.Run("#1: invalid input", func(t *testing.T) {
+		var n int
+		assert.ErrorIs(t, Fill(valOf(n), nil, FillOptions{}), ErrValueUnsettable)
+		assert.ErrorIs(t, Fill(valOf((*int)(nil)), nil, FillOptions{}), ErrValueInvalid)
+		assert.ErrorIs(t, Fill(valOf(nil), nil, FillOptions{}), ErrValueInvalid)
+		assert.ErrorIs(t, Fill(valOf(&n), nil, FillOptions{MinLen: 9}), ErrValueInvalid)
+	})
+
+	t.Run("#2: invalid constraints", func(t *testing.T) {
+		type Item1 struct {
+			A int `validate:"min=9,max=1"`
+		}
+		err := Fill(valOf(&Item1{}), nil, FillOptions{})
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		assert.ErrorContains(t, err, "path 'A'")
+
+		type Item2 struct {
+			A uint8 `validate:"max=300"`
+		}
+		assert.ErrorIs(t, Fill(valOf(&Item2{}), nil, FillOptions{}), ErrValueInvalid)
+
+		type Item3 struct {
+			A []int `fill:",min=x"`
+		}
+		assert.ErrorIs(t, Fill(valOf(&Item3{}), nil, FillOptions{}), ErrValueInvalid)
+
+		type Item4 struct {
+			A int `validate:"oneof=1 a"`
+		}
+		assert.ErrorIs(t, Fill(valOf(&Item4{}), nil, FillOptions{}), ErrValueInvalid)
+	})
+
+	t.Run("#3: required values exceed max depth", func(t *testing.T) {
+		type Node struct {
+			Next *Node `validate:"required"`
+		}
+		err := Fill(valOf(&Node{}), nil, FillOptions{MaxDepth: 3})
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		assert.ErrorContains(t, err, "path 'Next.Next.Next.Next'")
+
+		type Item struct {
+			A *int `validate:"required"`
+		}
+		err = Fill(valOf(&[]Item{}), nil, FillOptions{MinLen: 1, MaxDepth: 1})
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#4: generators", func(t *testing.T) {
+		type Item1 struct {
+			A string `fill:"unknown"`
+		}
+		assert.ErrorIs(t, Fill(valOf(&Item1{}), nil, FillOptions{}), ErrNotFound)
+
+		type Item2 struct {
+			A int `fill:"gen"`
+		}
+		err := Fill(valOf(&Item2{}), nil, FillOptions{NamedGenerators: map[string]FillGenerator{
+			"gen": func(*rand.Rand) any { return "abc" },
+		}})
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "path 'A'")
+	})
+}
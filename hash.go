@@ -0,0 +1,247 @@
+package rflutil
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	hashDefaultExcludeTag = "hash"
+)
+
+// markers written before values to distinguish nil values and cycles
+const (
+	hashMarkerNil byte = iota
+	hashMarkerValue
+	hashMarkerCycle
+)
+
+// HashOptions options of Hash and Hash256
+type HashOptions struct {
+	// ExcludeTag tag key to exclude struct fields, default is `hash`.
+	// Fields having tag value `-` are excluded.
+	ExcludeTag string
+	// IncludeUnexported includes unexported struct fields, they are excluded by default
+	IncludeUnexported bool
+}
+
+// Hash computes a deterministic 64-bit hash (FNV-1a) of a value based on its structure and content.
+// Map entries are hashed regardless of their order, pointers are hashed by the values they point to,
+// and interfaces are hashed by their underlying values. time.Time values are hashed by the time
+// instants. Struct fields are hashed with their names, and type names are not taken into account,
+// so values of different named types of the same kind and the same structure have the same hash.
+//
+// Values of func and chan kinds are unhashable, ErrTypeInvalid is returned if one is met
+// (nil ones are allowed), use the exclusion tag to skip struct fields of these kinds.
+// *TagSyntaxError is returned if a field has an invalid exclusion tag.
+func Hash(v reflect.Value, opts HashOptions) (uint64, error) {
+	h := newHasher(&opts, func() hash.Hash { return fnv.New64a() })
+	w := h.newHash()
+	if err := h.hash(w, v); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(w.Sum(nil)), nil
+}
+
+// Hash256 is the same as Hash, but it computes a 256-bit hash (SHA-256)
+func Hash256(v reflect.Value, opts HashOptions) ([32]byte, error) {
+	var ret [32]byte
+	h := newHasher(&opts, sha256.New)
+	w := h.newHash()
+	if err := h.hash(w, v); err != nil {
+		return ret, err
+	}
+	copy(ret[:], w.Sum(nil))
+	return ret, nil
+}
+
+type hasher struct {
+	opts    *HashOptions
+	newHash func() hash.Hash
+	onPath  map[visitedPtr]int // pointers, maps, and slices being hashed and their depths, to detect cycles
+}
+
+func newHasher(opts *HashOptions, newHash func() hash.Hash) *hasher {
+	if opts.ExcludeTag == "" {
+		opts.ExcludeTag = hashDefaultExcludeTag
+	}
+	return &hasher{opts: opts, newHash: newHash, onPath: map[visitedPtr]int{}}
+}
+
+func (h *hasher) writeUint64(w hash.Hash, u uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], u)
+	_, _ = w.Write(buf[:])
+}
+
+func (h *hasher) writeString(w hash.Hash, s string) {
+	h.writeUint64(w, uint64(len(s)))
+	_, _ = w.Write([]byte(s))
+}
+
+func (h *hasher) writeFloat(w hash.Hash, f float64) {
+	switch {
+	case f == 0:
+		f = 0 // -0 and +0 are the same
+	case math.IsNaN(f):
+		f = math.NaN()
+	}
+	h.writeUint64(w, math.Float64bits(f))
+}
+
+//nolint:gocyclo
+func (h *hasher) hash(w hash.Hash, v reflect.Value) error {
+	if !v.IsValid() {
+		_, _ = w.Write([]byte{hashMarkerNil, byte(reflect.Invalid)})
+		return nil
+	}
+	if isKindIn(v.Kind(), reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice,
+		reflect.Func, reflect.Chan, reflect.UnsafePointer) && v.IsNil() {
+		_, _ = w.Write([]byte{hashMarkerNil, byte(v.Kind())})
+		return nil
+	}
+	// Non-nil pointers and interfaces are transparent, they are hashed as the values they hold
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if h.enter(w, v) {
+			defer h.leave(v)
+			return h.hash(w, v.Elem())
+		}
+		return nil
+	case reflect.Interface:
+		return h.hash(w, v.Elem())
+	}
+	_, _ = w.Write([]byte{hashMarkerValue, byte(v.Kind())})
+
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		if v.Bool() {
+			h.writeUint64(w, 1)
+		} else {
+			h.writeUint64(w, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		h.writeUint64(w, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		h.writeUint64(w, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		h.writeFloat(w, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		h.writeFloat(w, real(v.Complex()))
+		h.writeFloat(w, imag(v.Complex()))
+	case reflect.String:
+		h.writeString(w, v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			if !h.enter(w, v) {
+				return nil
+			}
+			defer h.leave(v)
+		}
+		h.writeUint64(w, uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			if err := h.hash(w, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if h.enter(w, v) {
+			defer h.leave(v)
+			return h.hashMap(w, v)
+		}
+	case reflect.Struct:
+		return h.hashStruct(w, v)
+	default:
+		return fmt.Errorf("%w: unhashable type %v", ErrTypeInvalid, v.Type())
+	}
+	return nil
+}
+
+// enter marks a pointer, map, or slice being hashed. If it is already being hashed,
+// a cycle marker is written and false is returned.
+func (h *hasher) enter(w hash.Hash, v reflect.Value) bool {
+	key := visitedPtr{ptr: v.Pointer(), typ: v.Type()}
+	if depth, ok := h.onPath[key]; ok {
+		_, _ = w.Write([]byte{hashMarkerCycle})
+		h.writeUint64(w, uint64(depth))
+		return false
+	}
+	h.onPath[key] = len(h.onPath)
+	return true
+}
+
+func (h *hasher) leave(v reflect.Value) {
+	delete(h.onPath, visitedPtr{ptr: v.Pointer(), typ: v.Type()})
+}
+
+// hashMap hashes map entries separately, then combines the digests in sorted order
+func (h *hasher) hashMap(w hash.Hash, v reflect.Value) error {
+	digests := make([][]byte, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		entryHash := h.newHash()
+		if err := h.hash(entryHash, iter.Key()); err != nil {
+			return err
+		}
+		if err := h.hash(entryHash, iter.Value()); err != nil {
+			return err
+		}
+		digests = append(digests, entryHash.Sum(nil))
+	}
+	sort.Slice(digests, func(i, j int) bool {
+		return bytes.Compare(digests[i], digests[j]) < 0
+	})
+
+	h.writeUint64(w, uint64(len(digests)))
+	for _, digest := range digests {
+		_, _ = w.Write(digest)
+	}
+	return nil
+}
+
+func (h *hasher) hashStruct(w hash.Hash, v reflect.Value) error {
+	typ := v.Type()
+	if typ == timeType && v.CanInterface() {
+		t := v.Interface().(time.Time) //nolint:forcetypeassert
+		h.writeUint64(w, uint64(t.Unix()))
+		h.writeUint64(w, uint64(t.Nanosecond()))
+		return nil
+	}
+	if h.opts.IncludeUnexported && !v.CanAddr() {
+		addressable := reflect.New(typ).Elem()
+		addressable.Set(v)
+		v = addressable
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() && !h.opts.IncludeUnexported {
+			continue
+		}
+		tag, err := ParseTag(&sf, h.opts.ExcludeTag, ",")
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if tag != nil && tag.Ignored {
+			continue
+		}
+		field := v.Field(i)
+		if !sf.IsExported() {
+			field = makeAccessible(field)
+		}
+		h.writeString(w, sf.Name)
+		if err := h.hash(w, field); err != nil {
+			return fmt.Errorf("field '%s': %w", sf.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package rflutil
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustHash(t *testing.T, v any, opts HashOptions) uint64 {
+	h, err := Hash(valOf(v), opts)
+	assert.Nil(t, err)
+	return h
+}
+
+func Test_Hash(t *testing.T) {
+	type Item struct {
+		Name  string
+		Attrs map[string]any
+	}
+	type SS struct {
+		ID      int
+		Items   []*Item
+		Ptr     *int
+		Any     any
+		Updated time.Time `hash:"-"`
+		secret  string
+	}
+
+	t.Run("#1: deterministic", func(t *testing.T) {
+		n := 1
+		s1 := SS{ID: 1, Items: []*Item{{Name: "a", Attrs: map[string]any{"x": 1, "y": []int{1}}}}, Ptr: &n, Any: "a"}
+		n2 := 1
+		s2 := SS{ID: 1, Items: []*Item{{Name: "a", Attrs: map[string]any{"y": []int{1}, "x": 1}}}, Ptr: &n2, Any: "a"}
+		assert.Equal(t, mustHash(t, s1, HashOptions{}), mustHash(t, &s2, HashOptions{}))
+
+		s2.Items[0].Attrs["x"] = 2
+		assert.NotEqual(t, mustHash(t, s1, HashOptions{}), mustHash(t, s2, HashOptions{}))
+	})
+
+	t.Run("#2: maps are order-independent", func(t *testing.T) {
+		m1 := map[int]string{}
+		m2 := map[int]string{}
+		for i := 0; i < 100; i++ {
+			m1[i] = "v"
+			m2[99-i] = "v"
+		}
+		assert.Equal(t, mustHash(t, m1, HashOptions{}), mustHash(t, m2, HashOptions{}))
+	})
+
+	t.Run("#3: excluded and unexported fields", func(t *testing.T) {
+		s1 := SS{ID: 1, Updated: time.Now(), secret: "a"}
+		s2 := SS{ID: 1, secret: "b"}
+		assert.Equal(t, mustHash(t, s1, HashOptions{}), mustHash(t, s2, HashOptions{}))
+		assert.NotEqual(t, mustHash(t, s1, HashOptions{IncludeUnexported: true}),
+			mustHash(t, s2, HashOptions{IncludeUnexported: true}))
+
+		type SS2 struct {
+			A int `mytag:"-"`
+			B int
+		}
+		assert.Equal(t, mustHash(t, SS2{A: 1, B: 2}, HashOptions{ExcludeTag: "mytag"}),
+			mustHash(t, SS2{A: 2, B: 2}, HashOptions{ExcludeTag: "mytag"}))
+	})
+
+	t.Run("#4: distinct values", func(t *testing.T) {
+		values := []any{nil, 0, int64(0), uint(0), 0.0, false, "", "0", []int{}, []int(nil), []int{0},
+			map[string]int{}, [1]int{}, struct{ A int }{}, struct{ B int }{}, []string{"a", "b"}, []string{"ab"}}
+		hashes := map[uint64]any{}
+		for _, v := range values {
+			h := mustHash(t, v, HashOptions{})
+			assert.NotContains(t, hashes, h, "%#v collides with %#v", v, hashes[h])
+			hashes[h] = v
+		}
+	})
+
+	t.Run("#5: same content of different named types", func(t *testing.T) {
+		type MyInt int
+		assert.Equal(t, mustHash(t, 1, HashOptions{}), mustHash(t, MyInt(1), HashOptions{}))
+		assert.Equal(t, mustHash(t, 0.0, HashOptions{}), mustHash(t, math.Copysign(0, -1), HashOptions{}))
+		assert.Equal(t, mustHash(t, math.NaN(), HashOptions{}), mustHash(t, -math.NaN(), HashOptions{}))
+	})
+
+	t.Run("#6: time instants", func(t *testing.T) {
+		now := time.Now()
+		assert.Equal(t, mustHash(t, now, HashOptions{}), mustHash(t, now.UTC(), HashOptions{}))
+		assert.NotEqual(t, mustHash(t, now, HashOptions{}), mustHash(t, now.Add(1), HashOptions{}))
+	})
+
+	t.Run("#7: cycles", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+			Val  int
+		}
+		n1 := &Node{Val: 1}
+		n1.Next = n1
+		n2 := &Node{Val: 1}
+		n2.Next = n2
+		assert.Equal(t, mustHash(t, n1, HashOptions{}), mustHash(t, n2, HashOptions{}))
+
+		m := map[string]any{}
+		m["self"] = m
+		_ = mustHash(t, m, HashOptions{})
+
+		s := []any{nil}
+		s[0] = s
+		_ = mustHash(t, s, HashOptions{})
+	})
+
+	t.Run("#8: Hash256", func(t *testing.T) {
+		h1, err := Hash256(valOf(map[string]int{"a": 1, "b": 2}), HashOptions{})
+		assert.Nil(t, err)
+		h2, err := Hash256(valOf(map[string]int{"b": 2, "a": 1}), HashOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, h1, h2)
+		h3, err := Hash256(valOf(map[string]int{"b": 2, "a": 2}), HashOptions{})
+		assert.Nil(t, err)
+		assert.NotEqual(t, h1, h3)
+	})
+}
+
+func Test_Hash_failure(t *testing.T) {
+	type SS struct {
+		Fn func()
+	}
+	_, err := Hash(valOf(SS{Fn: func() {}}), HashOptions{})
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+	assert.ErrorContains(t, err, "field 'Fn'")
+
+	_, err = Hash(valOf(SS{}), HashOptions{})
+	assert.Nil(t, err)
+
+	_, err = Hash256(valOf(make(chan int)), HashOptions{})
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+
+	type TT struct {
+		A int `hash:"-,x='1'y"`
+	}
+	_, err = Hash(valOf(TT{}), HashOptions{})
+	assert.ErrorIs(t, err, ErrTagSyntax)
+	_, err = Hash256(valOf(&TT{}), HashOptions{})
+	assert.ErrorIs(t, err, ErrTagSyntax)
+}
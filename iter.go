@@ -0,0 +1,63 @@
+//go:build go1.23
+
+package rflutil
+
+import (
+	"iter"
+	"reflect"
+)
+
+// SliceValues returns an iterator over index and element of a slice or an array.
+// Input can be behind pointers and interfaces. Nothing is yielded for other types.
+func SliceValues(s reflect.Value) iter.Seq2[int, reflect.Value] {
+	return func(yield func(int, reflect.Value) bool) {
+		slice := indirectValueTilRoot(s)
+		if !slice.IsValid() || !isKindIn(slice.Kind(), reflect.Slice, reflect.Array) {
+			return
+		}
+		for i := 0; i < slice.Len(); i++ {
+			if !yield(i, slice.Index(i)) {
+				return
+			}
+		}
+	}
+}
+
+// MapAll returns an iterator over key and value of the entries of a map.
+// Input can be behind pointers and interfaces. Nothing is yielded for other types.
+func MapAll(m reflect.Value) iter.Seq2[reflect.Value, reflect.Value] {
+	return func(yield func(reflect.Value, reflect.Value) bool) {
+		val := indirectValueTilRoot(m)
+		if !val.IsValid() || val.Kind() != reflect.Map {
+			return
+		}
+		mapIter := val.MapRange()
+		for mapIter.Next() {
+			if !yield(mapIter.Key(), mapIter.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// StructFields returns an iterator over the fields of a struct and their values.
+// Input can be behind pointers and interfaces. Nothing is yielded for other types.
+// Unexported fields are made accessible when the struct is addressable.
+func StructFields(v reflect.Value) iter.Seq2[reflect.StructField, reflect.Value] {
+	return func(yield func(reflect.StructField, reflect.Value) bool) {
+		val := indirectValueTilRoot(v)
+		if !val.IsValid() || val.Kind() != reflect.Struct {
+			return
+		}
+		typ := val.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			field := val.Field(i)
+			if field.CanAddr() {
+				field = makeAccessible(field)
+			}
+			if !yield(typ.Field(i), field) {
+				return
+			}
+		}
+	}
+}
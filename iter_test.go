@@ -0,0 +1,109 @@
+//go:build go1.23
+
+package rflutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SliceValues(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		s := []string{"a", "b", "c"}
+		var result []string
+		for i, v := range SliceValues(valOf(&s)) {
+			assert.Equal(t, s[i], v.Interface())
+			result = append(result, v.String())
+		}
+		assert.Equal(t, s, result)
+	})
+
+	t.Run("#2: early break", func(t *testing.T) {
+		count := 0
+		for i := range SliceValues(valOf([3]int{1, 2, 3})) {
+			count++
+			if i == 1 {
+				break
+			}
+		}
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("#3: input is not slice", func(t *testing.T) {
+		count := 0
+		for range SliceValues(valOf("abc")) {
+			count++
+		}
+		assert.Equal(t, 0, count)
+	})
+}
+
+func Test_MapAll(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		m := map[string]int{"a": 1, "b": 2, "c": 3}
+		result := map[string]int{}
+		for k, v := range MapAll(valOf(m)) {
+			result[k.String()] = int(v.Int())
+		}
+		assert.Equal(t, m, result)
+	})
+
+	t.Run("#2: early break", func(t *testing.T) {
+		count := 0
+		for range MapAll(valOf(map[int]int{1: 1, 2: 2, 3: 3})) {
+			count++
+			break
+		}
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("#3: input is not map", func(t *testing.T) {
+		count := 0
+		for range MapAll(valOf([]int{1})) {
+			count++
+		}
+		assert.Equal(t, 0, count)
+	})
+}
+
+func Test_StructFields(t *testing.T) {
+	type SS struct {
+		I int
+		S string
+		u uint
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		s := SS{I: 1, S: "a", u: 2}
+		var names []string
+		var values []any
+		for sf, v := range StructFields(valOf(&s)) {
+			names = append(names, sf.Name)
+			values = append(values, v.Interface())
+		}
+		assert.Equal(t, []string{"I", "S", "u"}, names)
+		assert.Equal(t, []any{1, "a", uint(2)}, values)
+	})
+
+	t.Run("#2: unaddressable struct, early break", func(t *testing.T) {
+		var kinds []reflect.Kind
+		for sf, v := range StructFields(valOf(SS{})) {
+			if !sf.IsExported() {
+				assert.False(t, v.CanInterface())
+				break
+			}
+			kinds = append(kinds, v.Kind())
+		}
+		assert.Equal(t, []reflect.Kind{reflect.Int, reflect.String}, kinds)
+	})
+
+	t.Run("#3: input is not struct", func(t *testing.T) {
+		count := 0
+		for range StructFields(valOf(123)) {
+			count++
+		}
+		assert.Equal(t, 0, count)
+	})
+}
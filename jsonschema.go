@@ -0,0 +1,500 @@
+package rflutil
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	jsonSchemaDraft              = "https://json-schema.org/draft/2020-12/schema"
+	jsonSchemaDefaultValidateTag = "validate"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// JSONSchemaOptions options of JSONSchema
+type JSONSchemaOptions struct {
+	// Tags chain of tags to determine property names (see ParseTagChain), default is ["json"]
+	Tags []string
+	// ValidateTag tag key of validation attributes, default is `validate`, e.g. `validate:"min=1,max=9"`.
+	// Validation attributes can also be set in the tags of the chain, e.g. `json:"age,min=18"`.
+	ValidateTag string
+	// ID value of `$id` of the schema, omitted when empty
+	ID string
+}
+
+// JSONSchema generates a JSON schema (draft 2020-12) of a type, the schema describes the JSON
+// produced by encoding/json for the values of the type.
+//
+// Property names are taken from the tags, fields are required unless they have `omitempty` or
+// `omitzero`, the attribute `required` marks fields required explicitly. Embedded structs are
+// flattened unless they are named by the tags, the same as encoding/json does. Pointers are described
+// by the types they point to, time.Time as date-time strings, []byte as base64 strings,
+// encoding.TextMarshaler as strings, and json.Marshaler as any values. Recursive types are described
+// in `$defs`. Fields and items of pointer, slice, map types allow null (the root value doesn't),
+// except the fields having `omitempty` or `omitzero`.
+//
+// Supported validation attributes:
+//   - `min`, `max`: minimum, maximum of numbers, or minLength, maxLength of strings,
+//     or minItems, maxItems of slices, or minProperties, maxProperties of maps (omitted for
+//     the numbers encoded as strings with the option `string`)
+//   - `enum=[a,b]` or `oneof=a b`: enum of the allowed values
+//   - `pattern`, `format`, `description`: the same keywords of the schema
+//
+// Values of func, chan, and complex types result in ErrTypeInvalid.
+func JSONSchema(t reflect.Type, opts JSONSchemaOptions) (map[string]any, error) {
+	if len(opts.Tags) == 0 {
+		opts.Tags = []string{jsonTagName}
+	}
+	if opts.ValidateTag == "" {
+		opts.ValidateTag = jsonSchemaDefaultValidateTag
+	}
+	g := &jsonSchemaGenerator{
+		opts:       &opts,
+		root:       indirectTypeTilRoot(t),
+		defs:       map[string]any{},
+		defNames:   map[reflect.Type]string{},
+		inProgress: map[reflect.Type]bool{},
+	}
+
+	schema, err := g.schemaOf(t, "")
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]any{"$schema": jsonSchemaDraft}
+	if opts.ID != "" {
+		result["$id"] = opts.ID
+	}
+	for k, v := range schema {
+		result[k] = v
+	}
+	if len(g.defs) > 0 {
+		result["$defs"] = g.defs
+	}
+	return result, nil
+}
+
+type jsonSchemaGenerator struct {
+	opts       *JSONSchemaOptions
+	root       reflect.Type
+	defs       map[string]any
+	defNames   map[reflect.Type]string // names of the types described in defs
+	inProgress map[reflect.Type]bool   // struct types being described, true if they are referenced recursively
+}
+
+//nolint:gocyclo
+func (g *jsonSchemaGenerator) schemaOf(t reflect.Type, path string) (map[string]any, error) {
+	typ := indirectTypeTilRoot(t)
+	if typ == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}, nil
+	}
+	if typ.Implements(jsonMarshalerType) || reflect.PointerTo(typ).Implements(jsonMarshalerType) {
+		return map[string]any{}, nil
+	}
+	if typ.Implements(textMarshalerType) || reflect.PointerTo(typ).Implements(textMarshalerType) {
+		return map[string]any{"type": "string"}, nil
+	}
+
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]any{}, nil
+	case reflect.Slice, reflect.Array:
+		if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := g.schemaOf(typ.Elem(), path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		schema := map[string]any{"type": "array", "items": jsonSchemaNullable(typ.Elem(), items)}
+		if typ.Kind() == reflect.Array {
+			schema["minItems"] = typ.Len()
+			schema["maxItems"] = typ.Len()
+		}
+		return schema, nil
+	case reflect.Map:
+		if !isKindIn(typ.Key().Kind(), reflect.String, reflect.Int, reflect.Int8, reflect.Int16,
+			reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+			reflect.Uint64, reflect.Uintptr) && !typ.Key().Implements(textMarshalerType) {
+			return nil, fmt.Errorf("%w: unsupported map key type %v (path '%s')", ErrTypeInvalid, typ.Key(), path)
+		}
+		items, err := g.schemaOf(typ.Elem(), path+"[]")
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": jsonSchemaNullable(typ.Elem(), items)}, nil
+	case reflect.Struct:
+		return g.structSchemaOrRef(typ, path)
+	}
+	return nil, fmt.Errorf("%w: unsupported type %v (path '%s')", ErrTypeInvalid, typ, path)
+}
+
+// structSchemaOrRef describes a struct type. A recursive type is put in `$defs` (or it is the root),
+// a reference to it is returned instead.
+func (g *jsonSchemaGenerator) structSchemaOrRef(typ reflect.Type, path string) (map[string]any, error) {
+	if name, ok := g.defNames[typ]; ok {
+		return map[string]any{"$ref": "#/$defs/" + name}, nil
+	}
+	if _, ok := g.inProgress[typ]; ok {
+		g.inProgress[typ] = true
+		if typ == g.root {
+			return map[string]any{"$ref": "#"}, nil
+		}
+		return map[string]any{"$ref": "#/$defs/" + g.defName(typ)}, nil
+	}
+
+	g.inProgress[typ] = false
+	schema, err := g.structSchema(typ, path)
+	recursive := g.inProgress[typ]
+	delete(g.inProgress, typ)
+	if err != nil || !recursive || typ == g.root {
+		return schema, err
+	}
+	name := g.defName(typ)
+	g.defs[name] = schema
+	return map[string]any{"$ref": "#/$defs/" + name}, nil
+}
+
+// defName returns the unique name of a type in `$defs`
+func (g *jsonSchemaGenerator) defName(typ reflect.Type) string {
+	if name, ok := g.defNames[typ]; ok {
+		return name
+	}
+	base := strings.Map(func(r rune) rune {
+		if r == '_' || r == '.' || r == '-' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, typ.Name())
+	if base == "" {
+		base = "Struct" // unnamed struct types
+	}
+	name := base
+	for i := 2; g.isDefNameUsed(name); i++ { //nolint:mnd
+		name = base + strconv.Itoa(i)
+	}
+	g.defNames[typ] = name
+	return name
+}
+
+func (g *jsonSchemaGenerator) isDefNameUsed(name string) bool {
+	for _, n := range g.defNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *jsonSchemaGenerator) structSchema(typ reflect.Type, path string) (map[string]any, error) {
+	fields, err := g.structFields(typ)
+	if err != nil {
+		return nil, err
+	}
+	properties := make(map[string]any, len(fields))
+	required := make([]string, 0, len(fields))
+	for i := range fields {
+		sf, tag, name := &fields[i].field, fields[i].tag, fields[i].name
+		fieldPath := joinFieldPath(path, sf.Name)
+
+		schema, err := g.schemaOf(sf.Type, fieldPath)
+		if err != nil {
+			return nil, err
+		}
+		attrs, err := g.validationAttrs(sf, tag)
+		if err != nil {
+			return nil, fmt.Errorf("%w (path '%s')", err, fieldPath)
+		}
+		if tag != nil && tag.HasAttr("string") {
+			// Option `string` encodes booleans and numbers as strings
+			switch schema["type"] {
+			case "boolean", "integer", "number":
+				schema = map[string]any{"type": "string"}
+				// The limits of the numbers can't be expressed for their string forms,
+				// `min` and `max` must not become minLength and maxLength of the strings
+				delete(attrs, "min")
+				delete(attrs, "max")
+			}
+		}
+		if err = applyJSONSchemaAttrs(schema, indirectTypeTilRoot(sf.Type), attrs); err != nil {
+			return nil, fmt.Errorf("%w (path '%s')", err, fieldPath)
+		}
+
+		omitted := tag != nil && (tag.HasAttr("omitempty") || tag.HasAttr("omitzero"))
+		if !omitted {
+			// Nil values are encoded as null unless they are omitted
+			schema = jsonSchemaNullable(sf.Type, schema)
+		}
+		properties[name] = schema
+
+		if _, isRequired := attrs["required"]; isRequired || !omitted {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// jsonSchemaField a struct field encoded as a JSON property
+type jsonSchemaField struct {
+	field  reflect.StructField // Index is the index sequence from the struct
+	tag    *Tag
+	name   string
+	depth  int
+	tagged bool
+}
+
+// structFields lists the fields encoded as JSON properties the same way encoding/json does.
+// Embedded structs are flattened unless they are named by the tags. When multiple fields have
+// the same name, the shallowest one is used, the one named by the tags if there are many of them,
+// the others are omitted.
+//
+// NOTE: this deliberately doesn't flatten embedded structs the way structListFields does. That
+// one works on Go field names only: it flattens all embedded structs and lists each name once
+// regardless of the tags. The schema must describe what encoding/json produces, so embedded structs named by
+// the tags stay nested, ignored ones are skipped, and conflicts are resolved by depth and tags.
+func (g *jsonSchemaGenerator) structFields(typ reflect.Type) ([]jsonSchemaField, error) {
+	var fields []jsonSchemaField
+	if err := g.collectStructFields(typ, nil, map[reflect.Type]bool{}, &fields); err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string][]int, len(fields))
+	names := make([]string, 0, len(fields))
+	for i := range fields {
+		if _, ok := byName[fields[i].name]; !ok {
+			names = append(names, fields[i].name)
+		}
+		byName[fields[i].name] = append(byName[fields[i].name], i)
+	}
+	result := make([]jsonSchemaField, 0, len(names))
+	for _, name := range names {
+		if f, ok := dominantJSONSchemaField(fields, byName[name]); ok {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+func (g *jsonSchemaGenerator) collectStructFields(typ reflect.Type, index []int, visited map[reflect.Type]bool,
+	fields *[]jsonSchemaField) error {
+	if visited[typ] {
+		return nil // an embedded struct type embeds itself
+	}
+	visited[typ] = true
+	defer delete(visited, typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		rootType := indirectTypeTilRoot(sf.Type)
+		if (!sf.Anonymous || rootType.Kind() != reflect.Struct) && !sf.IsExported() {
+			continue
+		}
+		tag, err := ParseTagChain(&sf, g.opts.Tags, ",")
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if tag != nil && tag.Ignored {
+			continue
+		}
+		sf.Index = append(append(make([]int, 0, len(index)+1), index...), i)
+		tagged := tag != nil && tag.Name != ""
+		if sf.Anonymous && rootType.Kind() == reflect.Struct && !tagged {
+			if err = g.collectStructFields(rootType, sf.Index, visited, fields); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name := sf.Name
+		if tagged {
+			name = tag.Name
+		}
+		*fields = append(*fields, jsonSchemaField{field: sf, tag: tag, name: name, depth: len(index), tagged: tagged})
+	}
+	return nil
+}
+
+// dominantJSONSchemaField returns the field used among the fields having the same name
+func dominantJSONSchemaField(fields []jsonSchemaField, indexes []int) (jsonSchemaField, bool) {
+	minDepth := fields[indexes[0]].depth
+	for _, i := range indexes[1:] {
+		if fields[i].depth < minDepth {
+			minDepth = fields[i].depth
+		}
+	}
+	var candidates []jsonSchemaField
+	for _, i := range indexes {
+		if fields[i].depth == minDepth {
+			candidates = append(candidates, fields[i])
+		}
+	}
+	if len(candidates) > 1 {
+		tagged := candidates[:0:0]
+		for _, f := range candidates {
+			if f.tagged {
+				tagged = append(tagged, f)
+			}
+		}
+		candidates = tagged
+	}
+	if len(candidates) != 1 {
+		return jsonSchemaField{}, false
+	}
+	return candidates[0], true
+}
+
+// jsonSchemaNullable makes the schema of a type allow null when the values can be nil
+func jsonSchemaNullable(t reflect.Type, schema map[string]any) map[string]any {
+	if !isKindIn(t.Kind(), reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface) || len(schema) == 0 {
+		return schema
+	}
+	if typ, ok := schema["type"].(string); ok {
+		schema["type"] = []string{typ, "null"}
+		return schema
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
+// validationAttrs collects the validation attributes of a field from the tag of the chain
+// and the validation tag
+func (g *jsonSchemaGenerator) validationAttrs(sf *reflect.StructField, tag *Tag) (map[string]string, error) {
+	attrs := map[string]string{}
+	if tag != nil {
+		for k, v := range tag.Attrs {
+			attrs[k] = v
+		}
+	}
+	validateTag, err := parseAttrTag(sf, g.opts.ValidateTag, ",")
+	if err != nil || validateTag == nil {
+		return attrs, err
+	}
+	for k, v := range validateTag.Attrs {
+		attrs[k] = v
+	}
+	return attrs, nil
+}
+
+// applyJSONSchemaAttrs sets the schema keywords from the validation attributes
+//
+//nolint:gocyclo
+func applyJSONSchemaAttrs(schema map[string]any, typ reflect.Type, attrs map[string]string) error {
+	tag := &Tag{Attrs: attrs}
+	kind := typ.Kind()
+	if schema["type"] == "string" {
+		kind = reflect.String
+	}
+	for _, attr := range []string{"min", "max"} {
+		if !tag.HasAttr(attr) {
+			continue
+		}
+		var keyword string
+		switch kind { //nolint:exhaustive
+		case reflect.String:
+			keyword = attr + "Length"
+		case reflect.Slice, reflect.Array:
+			keyword = attr + "Items"
+		case reflect.Map:
+			keyword = attr + "Properties"
+		default:
+			f, err := tag.GetAttrFloat(attr)
+			if err != nil {
+				return err
+			}
+			schema[map[string]string{"min": "minimum", "max": "maximum"}[attr]] = f
+			continue
+		}
+		n, err := tag.GetAttrInt(attr)
+		if err != nil {
+			return err
+		}
+		schema[keyword] = n
+	}
+
+	enum, err := validationEnum(tag, kind)
+	if err != nil {
+		return err
+	}
+	if enum != nil {
+		schema["enum"] = enum
+	}
+
+	for _, keyword := range []string{"pattern", "format", "description"} {
+		if val, ok := attrs[keyword]; ok {
+			schema[keyword] = val
+		}
+	}
+	return nil
+}
+
+// validationEnum returns the allowed values set by the attribute `enum=[a,b]` or `oneof=a b`
+// parsed as values of the given kind, returns nil if the attributes are absent
+func validationEnum(tag *Tag, kind reflect.Kind) ([]any, error) {
+	var items []string
+	switch {
+	case tag.HasAttr("enum"):
+		list, err := tag.GetAttrList("enum")
+		if err != nil {
+			return nil, err
+		}
+		items = list
+	case tag.HasAttr("oneof"):
+		items = strings.Fields(tag.Attrs["oneof"])
+	default:
+		return nil, nil
+	}
+	values := make([]any, 0, len(items))
+	for _, s := range items {
+		val, err := parseEnumValue(kind, s)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, val)
+	}
+	return values, nil
+}
+
+// parseEnumValue parses an enum value of the given kind
+func parseEnumValue(kind reflect.Kind, s string) (any, error) {
+	var val any
+	var err error
+	switch kind { //nolint:exhaustive
+	case reflect.Bool:
+		val, err = strconv.ParseBool(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val, err = strconv.ParseInt(s, 10, 64) //nolint:mnd
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		val, err = strconv.ParseUint(s, 10, 64) //nolint:mnd
+	case reflect.Float32, reflect.Float64:
+		val, err = strconv.ParseFloat(s, 64) //nolint:mnd
+	default:
+		val = s
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: enum value '%s' (%v)", ErrValueInvalid, s, err)
+	}
+	return val, nil
+}
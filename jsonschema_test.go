@@ -0,0 +1,310 @@
+package rflutil
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_JSONSchema(t *testing.T) {
+	t.Run("#1: struct with tags and validation attributes", func(t *testing.T) {
+		type Base struct {
+			ID        int64     `json:"id"`
+			CreatedAt time.Time `json:"created_at,omitempty"`
+		}
+		type User struct {
+			Base
+			Name     string            `json:"name" validate:"min=1,max=50,pattern=^[a-z]+$"`
+			Age      *int              `json:"age,omitempty,min=18,max=150"`
+			Role     string            `json:"role,omitempty" validate:"oneof=admin user"`
+			Level    int               `json:"level,omitempty,required,enum=[1,2,3]"`
+			Tags     []string          `json:"tags,omitempty" validate:"max=10"`
+			Attrs    map[string]any    `json:"attrs,omitempty"`
+			Avatar   []byte            `json:"avatar,omitempty"`
+			IP       net.IP            `json:"ip,omitempty"`
+			Raw      json.RawMessage   `json:"raw,omitempty"`
+			Score    float64           `json:"score,string,omitempty"`
+			Pair     [2]bool           `json:"pair"`
+			Scores   map[int]float32   `json:"scores,omitempty" validate:"min=1"`
+			Ignored  string            `json:"-"`
+			NoTag    uint8             `validate:"description=no tag"`
+			internal string            //nolint:unused
+			Extra    map[string]string `json:"extra,omitempty" validate:"format=custom"`
+		}
+
+		schema, err := JSONSchema(reflect.TypeOf(&User{}), JSONSchemaOptions{ID: "https://example.com/user"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"$id":     "https://example.com/user",
+			"type":    "object",
+			"properties": map[string]any{
+				"id":         map[string]any{"type": "integer"},
+				"created_at": map[string]any{"type": "string", "format": "date-time"},
+				"name": map[string]any{"type": "string", "minLength": 1, "maxLength": 50,
+					"pattern": "^[a-z]+$"},
+				"age":    map[string]any{"type": "integer", "minimum": 18.0, "maximum": 150.0},
+				"role":   map[string]any{"type": "string", "enum": []any{"admin", "user"}},
+				"level":  map[string]any{"type": "integer", "enum": []any{int64(1), int64(2), int64(3)}},
+				"tags":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "maxItems": 10},
+				"attrs":  map[string]any{"type": "object", "additionalProperties": map[string]any{}},
+				"avatar": map[string]any{"type": "string", "contentEncoding": "base64"},
+				"ip":     map[string]any{"type": "string"},
+				"raw":    map[string]any{},
+				"score":  map[string]any{"type": "string"},
+				"pair": map[string]any{"type": "array", "items": map[string]any{"type": "boolean"},
+					"minItems": 2, "maxItems": 2},
+				"scores": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "number"},
+					"minProperties": 1},
+				"NoTag": map[string]any{"type": "integer", "description": "no tag"},
+				"extra": map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"},
+					"format": "custom"},
+			},
+			"required": []string{"id", "name", "level", "pair", "NoTag"},
+		}, schema)
+
+		// The schema must be serializable
+		_, err = json.Marshal(schema)
+		assert.Nil(t, err)
+	})
+
+	t.Run("#2: recursive root type", func(t *testing.T) {
+		type Node struct {
+			Value    int     `json:"value"`
+			Children []*Node `json:"children,omitempty"`
+		}
+		schema, err := JSONSchema(reflect.TypeOf(Node{}), JSONSchemaOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type":    "object",
+			"properties": map[string]any{
+				"value": map[string]any{"type": "integer"},
+				"children": map[string]any{"type": "array", "items": map[string]any{
+					"anyOf": []any{map[string]any{"$ref": "#"}, map[string]any{"type": "null"}},
+				}},
+			},
+			"required": []string{"value"},
+		}, schema)
+	})
+
+	t.Run("#3: recursive nested types in $defs", func(t *testing.T) {
+		type Category struct {
+			Name   string    `json:"name"`
+			Parent *Category `json:"parent,omitempty"`
+		}
+		type Product struct {
+			Category  Category   `json:"category"`
+			Related   []Category `json:"related"`
+			Secondary struct {
+				Name string `json:"name"`
+			} `json:"secondary"`
+		}
+		schema, err := JSONSchema(reflect.TypeOf(Product{}), JSONSchemaOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type":    "object",
+			"properties": map[string]any{
+				"category": map[string]any{"$ref": "#/$defs/Category"},
+				"related": map[string]any{"type": []string{"array", "null"},
+					"items": map[string]any{"$ref": "#/$defs/Category"}},
+				"secondary": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"name": map[string]any{"type": "string"}},
+					"required":   []string{"name"},
+				},
+			},
+			"required": []string{"category", "related", "secondary"},
+			"$defs": map[string]any{
+				"Category": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"name":   map[string]any{"type": "string"},
+						"parent": map[string]any{"$ref": "#/$defs/Category"},
+					},
+					"required": []string{"name"},
+				},
+			},
+		}, schema)
+	})
+
+	t.Run("#4: custom tags", func(t *testing.T) {
+		type Item struct {
+			A string `yaml:"a" json:"x"`
+			B string `json:"b,omitempty" check:"max=3"`
+		}
+		schema, err := JSONSchema(reflect.TypeOf(Item{}), JSONSchemaOptions{
+			Tags:        []string{"yaml", "json"},
+			ValidateTag: "check",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"a": map[string]any{"type": "string"},
+			"b": map[string]any{"type": "string", "maxLength": 3},
+		}, schema["properties"])
+		assert.Equal(t, []string{"a"}, schema["required"])
+	})
+
+	t.Run("#5: non-struct types", func(t *testing.T) {
+		schema, err := JSONSchema(reflect.TypeOf([]map[string]int{}), JSONSchemaOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type":    "array",
+			"items": map[string]any{"type": []string{"object", "null"},
+				"additionalProperties": map[string]any{"type": "integer"}},
+		}, schema)
+	})
+
+	t.Run("#6: numbers encoded as strings", func(t *testing.T) {
+		type Item struct {
+			Age   int    `json:"age,string" validate:"min=18,max=99"`
+			Level *int   `json:"level,string,omitempty" validate:"oneof=1 2"`
+			Code  string `json:"code,string" validate:"min=2"`
+		}
+		schema, err := JSONSchema(reflect.TypeOf(Item{}), JSONSchemaOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"age":   map[string]any{"type": "string"},
+			"level": map[string]any{"type": "string", "enum": []any{"1", "2"}},
+			"code":  map[string]any{"type": "string", "minLength": 2},
+		}, schema["properties"])
+	})
+}
+
+func Test_JSONSchema_nullable(t *testing.T) {
+	type Node struct {
+		Name string `json:"name"`
+	}
+	type Item struct {
+		P     *int              `json:"p"`
+		S     []string          `json:"s" validate:"max=3"`
+		M     map[string]*Node  `json:"m"`
+		A     any               `json:"a"`
+		T     *time.Time        `json:"t"`
+		N     *Node             `json:"n"`
+		Self  *Item             `json:"self"`
+		Opt   *int              `json:"opt,omitempty"`
+		Slice []*int            `json:"slice,omitzero"`
+		Arr   [1]map[string]int `json:"arr"`
+	}
+	schema, err := JSONSchema(reflect.TypeOf(&Item{}), JSONSchemaOptions{})
+	assert.Nil(t, err)
+	nodeSchema := map[string]any{
+		"type":       []string{"object", "null"},
+		"properties": map[string]any{"name": map[string]any{"type": "string"}},
+		"required":   []string{"name"},
+	}
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, map[string]any{
+		"p": map[string]any{"type": []string{"integer", "null"}},
+		"s": map[string]any{"type": []string{"array", "null"}, "items": map[string]any{"type": "string"},
+			"maxItems": 3},
+		"m":     map[string]any{"type": []string{"object", "null"}, "additionalProperties": nodeSchema},
+		"a":     map[string]any{},
+		"t":     map[string]any{"type": []string{"string", "null"}, "format": "date-time"},
+		"n":     nodeSchema,
+		"self":  map[string]any{"anyOf": []any{map[string]any{"$ref": "#"}, map[string]any{"type": "null"}}},
+		"opt":   map[string]any{"type": "integer"},
+		"slice": map[string]any{"type": "array", "items": map[string]any{"type": []string{"integer", "null"}}},
+		"arr": map[string]any{"type": "array", "minItems": 1, "maxItems": 1, "items": map[string]any{
+			"type": []string{"object", "null"}, "additionalProperties": map[string]any{"type": "integer"},
+		}},
+	}, schema["properties"])
+	assert.Equal(t, []string{"p", "s", "m", "a", "t", "n", "self", "arr"}, schema["required"])
+}
+
+func Test_JSONSchema_embeddedStructs(t *testing.T) {
+	type Base struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type Audit struct {
+		By   string `json:"by"`
+		Name string `json:"name"`
+	}
+	type Meta struct {
+		Version int `json:"version"`
+	}
+	type Ign struct {
+		X int `json:"x"`
+	}
+	type Item struct {
+		Base
+		*Audit
+		Meta  `json:"meta"`
+		Ign   `json:"-"`
+		Label string `json:"label"`
+	}
+	schema, err := JSONSchema(reflect.TypeOf(Item{}), JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{
+		"id": map[string]any{"type": "integer"},
+		"by": map[string]any{"type": "string"},
+		"meta": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"version": map[string]any{"type": "integer"}},
+			"required":   []string{"version"},
+		},
+		"label": map[string]any{"type": "string"},
+	}, schema["properties"])
+	assert.Equal(t, []string{"id", "by", "meta", "label"}, schema["required"])
+
+	// The same as encoding/json does
+	data, err := json.Marshal(Item{Base: Base{ID: 1, Name: "x"}, Audit: &Audit{By: "y"}})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":1,"by":"y","meta":{"version":0},"label":""}`, string(data))
+}
+
+func Test_jsonSchemaGenerator_defName(t *testing.T) {
+	type Item struct{}
+	g := &jsonSchemaGenerator{defNames: map[reflect.Type]string{}}
+	unnamed1 := reflect.TypeOf(struct{ A int }{})
+	unnamed2 := reflect.TypeOf(struct{ B int }{})
+	assert.Equal(t, "Struct", g.defName(unnamed1))
+	assert.Equal(t, "Struct2", g.defName(unnamed2))
+	assert.Equal(t, "Struct", g.defName(unnamed1))
+	assert.Equal(t, "Item", g.defName(reflect.TypeOf(Item{})))
+}
+
+func Test_JSONSchema_failure(t *testing.T) {
+	t.Run("#1: unsupported type", func(t *testing.T) {
+		type Item struct {
+			Fn func() `json:"fn"`
+		}
+		_, err := JSONSchema(reflect.TypeOf(Item{}), JSONSchemaOptions{})
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		assert.ErrorContains(t, err, "path 'Fn'")
+
+		_, err = JSONSchema(reflect.TypeOf(map[[2]int]string{}), JSONSchemaOptions{})
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: invalid attribute values", func(t *testing.T) {
+		type Item struct {
+			A int `validate:"min=x"`
+		}
+		_, err := JSONSchema(reflect.TypeOf(Item{}), JSONSchemaOptions{})
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		assert.ErrorContains(t, err, "path 'A'")
+
+		type Item2 struct {
+			A int `validate:"oneof=1 x"`
+		}
+		_, err = JSONSchema(reflect.TypeOf(Item2{}), JSONSchemaOptions{})
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#3: invalid tag syntax", func(t *testing.T) {
+		type Item struct {
+			A int `validate:"min='1'x"`
+		}
+		_, err := JSONSchema(reflect.TypeOf(Item{}), JSONSchemaOptions{})
+		assert.ErrorIs(t, err, ErrTagSyntax)
+	})
+}
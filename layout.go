@@ -0,0 +1,141 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	layoutColumnPadding = 2
+)
+
+// FieldLayout memory layout of a struct field
+type FieldLayout struct {
+	Name    string
+	Type    reflect.Type
+	Offset  uintptr
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr // padding bytes after the field
+}
+
+// LayoutReport memory layout of a type reported by Layout
+type LayoutReport struct {
+	Type    reflect.Type
+	Size    uintptr
+	Align   uintptr
+	Padding uintptr // total padding bytes between and after the fields
+	Fields  []FieldLayout
+
+	// OptimalOrder field names in the order minimizing the struct size
+	OptimalOrder []string
+	// OptimalSize struct size when the fields are in the optimal order
+	OptimalSize uintptr
+}
+
+// Layout reports the memory layout of a struct type on the current platform: the offset, size,
+// alignment of the fields, and the padding between them. It also suggests a field order that
+// minimizes the struct size, which is placing the fields in descending order of alignments.
+// Pointers are dereferenced. Types other than struct are reported without fields.
+func Layout(t reflect.Type) LayoutReport {
+	typ := indirectTypeTilRoot(t)
+	report := LayoutReport{
+		Type:        typ,
+		Size:        typ.Size(),
+		Align:       uintptr(typ.Align()),
+		OptimalSize: typ.Size(),
+	}
+	if typ.Kind() != reflect.Struct {
+		return report
+	}
+
+	fields := make([]FieldLayout, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		fields = append(fields, FieldLayout{
+			Name:   sf.Name,
+			Type:   sf.Type,
+			Offset: sf.Offset,
+			Size:   sf.Type.Size(),
+			Align:  uintptr(sf.Type.Align()),
+		})
+	}
+	for i := range fields {
+		end := typ.Size()
+		if i+1 < len(fields) {
+			end = fields[i+1].Offset
+		}
+		fields[i].Padding = end - fields[i].Offset - fields[i].Size
+		report.Padding += fields[i].Padding
+	}
+	report.Fields = fields
+
+	// Zero-size fields go first, as a zero-size final field gets padded
+	optimal := make([]FieldLayout, len(fields))
+	copy(optimal, fields)
+	sort.SliceStable(optimal, func(i, j int) bool {
+		if (optimal[i].Size == 0) != (optimal[j].Size == 0) {
+			return optimal[i].Size == 0
+		}
+		return optimal[i].Align > optimal[j].Align
+	})
+	report.OptimalOrder = make([]string, 0, len(optimal))
+	for _, f := range optimal {
+		report.OptimalOrder = append(report.OptimalOrder, f.Name)
+	}
+	report.OptimalSize = layoutStructSize(optimal, report.Align)
+	return report
+}
+
+// layoutStructSize calculates the size of a struct having the fields in the given order,
+// the same way the compiler does
+func layoutStructSize(fields []FieldLayout, align uintptr) uintptr {
+	var offset uintptr
+	for _, f := range fields {
+		offset = alignUp(offset, f.Align) + f.Size
+	}
+	if len(fields) > 0 && fields[len(fields)-1].Size == 0 && offset > 0 {
+		offset++
+	}
+	return alignUp(offset, align)
+}
+
+func alignUp(n, align uintptr) uintptr {
+	if align <= 1 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+// String formats the report as a table, for example:
+//
+//	main.Item: size=24 align=8 padding=14
+//	OFFSET  SIZE  ALIGN  PADDING  FIELD
+//	0       1     1      7        A bool
+//	8       8     8      0        B int64
+//	16      1     1      7        C bool
+//	optimal order (size=16): B, A, C
+func (r LayoutReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v: size=%d align=%d padding=%d\n", r.Type, r.Size, r.Align, r.Padding)
+	if r.Type == nil || r.Type.Kind() != reflect.Struct {
+		return sb.String()
+	}
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, layoutColumnPadding, ' ', 0)
+	fmt.Fprintln(tw, "OFFSET\tSIZE\tALIGN\tPADDING\tFIELD")
+	for _, f := range r.Fields {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%d\t%s %v\n", f.Offset, f.Size, f.Align, f.Padding, f.Name, f.Type)
+	}
+	_ = tw.Flush()
+
+	if r.OptimalSize < r.Size {
+		fmt.Fprintf(&sb, "optimal order (size=%d): %s\n", r.OptimalSize, strings.Join(r.OptimalOrder, ", "))
+	} else {
+		sb.WriteString("the field order is optimal\n")
+	}
+	return sb.String()
+}
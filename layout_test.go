@@ -0,0 +1,84 @@
+package rflutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Layout(t *testing.T) {
+	t.Run("#1: struct with padding", func(t *testing.T) {
+		type Item struct {
+			A bool
+			B int64
+			C bool
+			D int32
+			e uint16
+		}
+		r := Layout(reflect.TypeOf(&Item{}))
+		assert.Equal(t, reflect.TypeOf(Item{}), r.Type)
+		assert.Equal(t, uintptr(32), r.Size)
+		assert.Equal(t, uintptr(8), r.Align)
+		assert.Equal(t, uintptr(16), r.Padding)
+		assert.Equal(t, []FieldLayout{
+			{Name: "A", Type: reflect.TypeOf(false), Offset: 0, Size: 1, Align: 1, Padding: 7},
+			{Name: "B", Type: reflect.TypeOf(int64(0)), Offset: 8, Size: 8, Align: 8, Padding: 0},
+			{Name: "C", Type: reflect.TypeOf(false), Offset: 16, Size: 1, Align: 1, Padding: 3},
+			{Name: "e", Type: reflect.TypeOf(uint16(0)), Offset: 24, Size: 2, Align: 2, Padding: 6},
+		}, []FieldLayout{r.Fields[0], r.Fields[1], r.Fields[2], r.Fields[4]})
+		assert.Equal(t, []string{"B", "D", "e", "A", "C"}, r.OptimalOrder)
+		assert.Equal(t, uintptr(16), r.OptimalSize)
+	})
+
+	t.Run("#2: optimal order verified with StructOf", func(t *testing.T) {
+		type Item struct {
+			A byte
+			B [3]int32
+			C string
+			D int16
+			E struct{}
+		}
+		r := Layout(reflect.TypeOf(Item{}))
+		assert.Equal(t, []string{"E", "C", "B", "D", "A"}, r.OptimalOrder)
+
+		fields := make([]reflect.StructField, 0, len(r.OptimalOrder))
+		for _, name := range r.OptimalOrder {
+			sf, _ := reflect.TypeOf(Item{}).FieldByName(name)
+			fields = append(fields, reflect.StructField{Name: sf.Name, Type: sf.Type})
+		}
+		assert.Equal(t, reflect.StructOf(fields).Size(), r.OptimalSize)
+		assert.Less(t, r.OptimalSize, r.Size)
+	})
+
+	t.Run("#3: optimal struct", func(t *testing.T) {
+		type Item struct {
+			A int64
+			B bool
+		}
+		r := Layout(reflect.TypeOf(Item{}))
+		assert.Equal(t, r.Size, r.OptimalSize)
+		assert.Contains(t, r.String(), "the field order is optimal")
+	})
+
+	t.Run("#4: non-struct type", func(t *testing.T) {
+		r := Layout(reflect.TypeOf(int32(0)))
+		assert.Equal(t, uintptr(4), r.Size)
+		assert.Nil(t, r.Fields)
+		assert.Equal(t, "int32: size=4 align=4 padding=0\n", r.String())
+	})
+}
+
+func Test_LayoutReport_String(t *testing.T) {
+	type Item struct {
+		A bool
+		B int64
+		C bool
+	}
+	assert.Equal(t, "rflutil.Item: size=24 align=8 padding=14\n"+
+		"OFFSET  SIZE  ALIGN  PADDING  FIELD\n"+
+		"0       1     1      7        A bool\n"+
+		"8       8     8      0        B int64\n"+
+		"16      1     1      7        C bool\n"+
+		"optimal order (size=16): B, A, C\n", Layout(reflect.TypeOf(Item{})).String())
+}
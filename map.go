@@ -3,6 +3,7 @@ package rflutil
 import (
 	"fmt"
 	"reflect"
+	"sort"
 )
 
 type MapEntry struct {
@@ -10,8 +11,11 @@ type MapEntry struct {
 	Value reflect.Value
 }
 
-// MapLen get number of entries in a map
+// MapLen get number of entries in a map. Input can be a map or a MapLike such as *sync.Map.
 func MapLen(m reflect.Value) (int, error) {
+	if ml := mapLikeOf(m); ml != nil {
+		return mapLikeLen(ml), nil
+	}
 	val := indirectValueTilRoot(m)
 	if !val.IsValid() || val.Kind() != reflect.Map {
 		return 0, fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, m.Type())
@@ -19,8 +23,11 @@ func MapLen(m reflect.Value) (int, error) {
 	return val.Len(), nil
 }
 
-// MapGet get value from a map by key
+// MapGet get value from a map by key. Input can be a map or a MapLike such as *sync.Map.
 func MapGet[V any, K comparable](m reflect.Value, k K) (V, error) {
+	if ml := mapLikeOf(m); ml != nil {
+		return mapLikeGet[V](ml, k)
+	}
 	var ret V
 	val := indirectValueTilRoot(m)
 	if !val.IsValid() || val.Kind() != reflect.Map {
@@ -29,9 +36,9 @@ func MapGet[V any, K comparable](m reflect.Value, k K) (V, error) {
 
 	mapType := val.Type()
 	keyVal := reflect.ValueOf(k)
-	if !mapType.Key().AssignableTo(keyVal.Type()) {
+	if !keyVal.Type().AssignableTo(mapType.Key()) {
 		return ret, fmt.Errorf("%w: key type is %v (expect %v)", ErrTypeUnmatched,
-			mapType.Key(), keyVal.Type())
+			keyVal.Type(), mapType.Key())
 	}
 
 	valueVal := val.MapIndex(keyVal)
@@ -46,37 +53,106 @@ func MapGet[V any, K comparable](m reflect.Value, k K) (V, error) {
 	return ret, nil
 }
 
-// MapSet set value for a key of a map
-func MapSet[K comparable, V any](m reflect.Value, k K, v V) error {
-	val := indirectValueTilRoot(m)
-	if !val.IsValid() || val.Kind() != reflect.Map {
-		return fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, m.Type())
+// MapSet set value for a key of a map. Input can be a map or a MapLike such as *sync.Map.
+// Setting to a nil map results in ErrValueInvalid unless option WithAutoInit is used and the map is settable.
+func MapSet[K comparable, V any](m reflect.Value, k K, v V, opts ...Option) error {
+	if ml := mapLikeOf(m); ml != nil {
+		ml.Store(k, v)
+		return nil
+	}
+	val, err := mapForSet(m, opts)
+	if err != nil {
+		return err
 	}
 
 	mapType := val.Type()
 	keyVal := reflect.ValueOf(k)
-	if !mapType.Key().AssignableTo(keyVal.Type()) {
+	if !keyVal.Type().AssignableTo(mapType.Key()) {
 		return fmt.Errorf("%w: key type is %v (expect %v)", ErrTypeUnmatched,
-			mapType.Key(), keyVal.Type())
+			keyVal.Type(), mapType.Key())
 	}
-	valVal := reflect.ValueOf(v)
-	if !valVal.IsValid() {
-		if mapType.Elem().Kind() == reflect.Interface {
-			val.SetMapIndex(keyVal, reflect.Zero(mapType.Elem()))
-			return nil
-		}
+	valVal, err := valueToAssign(v, mapType.Elem())
+	if err != nil {
+		return err
+	}
+
+	val.SetMapIndex(keyVal, valVal)
+	return nil
+}
+
+// MapGetAs get value from a map by key with converting the key to the map key type and
+// converting the value to V type (see ValueAs). For example, an int key can be used to access
+// a map[int64]X, or a string key can be used to access a map of a named string key type.
+// Unlike ValueAs, numbers are not converted to strings as runes (65 is not converted to "A").
+func MapGetAs[V any](m reflect.Value, k any) (V, error) {
+	var ret V
+	val := indirectValueTilRoot(m)
+	if !val.IsValid() || val.Kind() != reflect.Map {
+		return ret, fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, m.Type())
+	}
+
+	keyVal, err := valueConvert(reflect.ValueOf(k), val.Type().Key(), true)
+	if err != nil {
+		return ret, fmt.Errorf("key conversion failed: %w", err)
+	}
+	valueVal := val.MapIndex(keyVal)
+	if !valueVal.IsValid() {
+		return ret, ErrNotFound
+	}
+	valueVal, err = valueConvert(valueVal, reflect.TypeOf((*V)(nil)).Elem(), true)
+	if err != nil {
+		return ret, fmt.Errorf("value conversion failed: %w", err)
+	}
+	ret, _ = valueVal.Interface().(V)
+	return ret, nil
+}
+
+// MapSetAs set value for a key of a map with converting the key and the value to the map key
+// and element types (see MapGetAs). Nil maps are handled the same as MapSet does.
+func MapSetAs(m reflect.Value, k, v any, opts ...Option) error {
+	val, err := mapForSet(m, opts)
+	if err != nil {
+		return err
+	}
+
+	mapType := val.Type()
+	keyVal, err := valueConvert(reflect.ValueOf(k), mapType.Key(), true)
+	if err != nil {
+		return fmt.Errorf("key conversion failed: %w", err)
 	}
-	if !mapType.Elem().AssignableTo(valVal.Type()) {
-		return fmt.Errorf("%w: value type is %v (expect %v)", ErrTypeUnmatched,
-			mapType.Elem(), valVal.Type())
+	valVal, err := valueConvert(reflect.ValueOf(v), mapType.Elem(), true)
+	if err != nil {
+		return fmt.Errorf("value conversion failed: %w", err)
 	}
 
 	val.SetMapIndex(keyVal, valVal)
 	return nil
 }
 
-// MapDelete delete the given key from a map
+// mapForSet gets the map to set entries, a nil map is allocated if option WithAutoInit is used
+func mapForSet(m reflect.Value, opts []Option) (reflect.Value, error) {
+	autoInit := newOptions(opts).autoInit
+	val := indirectValueTilRootEx(m, autoInit)
+	if !val.IsValid() || val.Kind() != reflect.Map {
+		return reflect.Value{}, fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, m.Type())
+	}
+	if val.IsNil() {
+		if autoInit {
+			initNilValue(val)
+		}
+		if val.IsNil() {
+			return reflect.Value{}, fmt.Errorf("%w: map is nil", ErrValueInvalid)
+		}
+	}
+	return val, nil
+}
+
+// MapDelete delete the given key from a map. Input can be a map or a MapLike such as *sync.Map.
 func MapDelete[K comparable](m reflect.Value, k K) error {
+	if ml := mapLikeOf(m); ml != nil {
+		ml.Delete(k)
+		return nil
+	}
 	val := indirectValueTilRoot(m)
 	if !val.IsValid() || val.Kind() != reflect.Map {
 		return fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, m.Type())
@@ -84,17 +160,25 @@ func MapDelete[K comparable](m reflect.Value, k K) error {
 
 	mapType := val.Type()
 	keyVal := reflect.ValueOf(k)
-	if !mapType.Key().AssignableTo(keyVal.Type()) {
+	if !keyVal.Type().AssignableTo(mapType.Key()) {
 		return fmt.Errorf("%w: key type is %v (expect %v)", ErrTypeUnmatched,
-			mapType.Key(), keyVal.Type())
+			keyVal.Type(), mapType.Key())
 	}
 	// Set zero value means delete the key from the map
 	val.SetMapIndex(keyVal, reflect.Value{})
 	return nil
 }
 
-// MapKeys get all keys of a map
+// MapKeys get all keys of a map. Input can be a map or a MapLike such as *sync.Map.
 func MapKeys(m reflect.Value) ([]reflect.Value, error) {
+	if ml := mapLikeOf(m); ml != nil {
+		entries := mapLikeEntries(ml)
+		keys := make([]reflect.Value, len(entries))
+		for i := range entries {
+			keys[i] = entries[i].Key
+		}
+		return keys, nil
+	}
 	val := indirectValueTilRoot(m)
 	if !val.IsValid() || val.Kind() != reflect.Map {
 		return nil, fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, m.Type())
@@ -102,8 +186,11 @@ func MapKeys(m reflect.Value) ([]reflect.Value, error) {
 	return val.MapKeys(), nil
 }
 
-// MapEntries get all entries of a map
+// MapEntries get all entries of a map. Input can be a map or a MapLike such as *sync.Map.
 func MapEntries(m reflect.Value) ([]MapEntry, error) {
+	if ml := mapLikeOf(m); ml != nil {
+		return mapLikeEntries(ml), nil
+	}
 	val := indirectValueTilRoot(m)
 	if !val.IsValid() || val.Kind() != reflect.Map {
 		return nil, fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, m.Type())
@@ -119,3 +206,43 @@ func MapEntries(m reflect.Value) ([]MapEntry, error) {
 	}
 	return result, nil
 }
+
+// MapKeysSorted get all keys of a map in natural order. Strings, numbers, and bools are ordered
+// by their values, structs and arrays are ordered field by field (element by element).
+// Keys of different types (in maps of interface key type) are ordered by their kinds first.
+func MapKeysSorted(m reflect.Value) ([]reflect.Value, error) {
+	return MapKeysSortedFunc(m, mapKeyLess)
+}
+
+// MapKeysSortedFunc get all keys of a map in the order determined by the given less function
+func MapKeysSortedFunc(m reflect.Value, less func(k1, k2 reflect.Value) bool) ([]reflect.Value, error) {
+	keys, err := MapKeys(m)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return less(keys[i], keys[j])
+	})
+	return keys, nil
+}
+
+// MapEntriesSorted get all entries of a map in natural order of keys (see MapKeysSorted)
+func MapEntriesSorted(m reflect.Value) ([]MapEntry, error) {
+	return MapEntriesSortedFunc(m, mapKeyLess)
+}
+
+// MapEntriesSortedFunc get all entries of a map in the order of keys determined by the given less function
+func MapEntriesSortedFunc(m reflect.Value, less func(k1, k2 reflect.Value) bool) ([]MapEntry, error) {
+	entries, err := MapEntries(m)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i].Key, entries[j].Key)
+	})
+	return entries, nil
+}
+
+func mapKeyLess(k1, k2 reflect.Value) bool {
+	return compareValues(k1, k2) < 0
+}
@@ -0,0 +1,92 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MapLike an interface of map-like containers such as *sync.Map. The map functions MapLen, MapGet,
+// MapSet, MapDelete, MapKeys, and MapEntries accept values implementing this interface.
+type MapLike interface {
+	Load(key any) (value any, ok bool)
+	Store(key, value any)
+	Delete(key any)
+	Range(f func(key, value any) bool)
+}
+
+var mapLikeType = reflect.TypeOf((*MapLike)(nil)).Elem()
+
+// mapLikeOf gets the MapLike implementation of a value with dereferencing pointers and interfaces
+// till one is found. Returns nil if not found or the implementation is a nil pointer.
+func mapLikeOf(v reflect.Value) MapLike {
+	for v.IsValid() {
+		if v.CanInterface() {
+			if v.Type().Implements(mapLikeType) {
+				if v.Kind() == reflect.Pointer && v.IsNil() {
+					return nil
+				}
+				ml, _ := v.Interface().(MapLike)
+				return ml
+			}
+			// E.g. an addressable sync.Map
+			if v.Kind() != reflect.Pointer && v.CanAddr() && reflect.PointerTo(v.Type()).Implements(mapLikeType) {
+				return v.Addr().Interface().(MapLike) //nolint:forcetypeassert
+			}
+		}
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			break
+		}
+		v = v.Elem()
+	}
+	return nil
+}
+
+func mapLikeLen(ml MapLike) int {
+	n := 0
+	ml.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+func mapLikeGet[V any](ml MapLike, k any) (V, error) {
+	var ret V
+	value, ok := ml.Load(k)
+	if !ok {
+		return ret, ErrNotFound
+	}
+	retType := reflect.TypeOf((*V)(nil)).Elem()
+	if value == nil {
+		// A stored nil is the zero value of V when V is nillable
+		if isKindIn(retType.Kind(), reflect.Interface, reflect.Pointer, reflect.Slice, reflect.Map,
+			reflect.Chan, reflect.Func, reflect.UnsafePointer) {
+			return ret, nil
+		}
+		return ret, fmt.Errorf("%w: value is nil (expect %v)", ErrTypeUnmatched, retType)
+	}
+	ret, ok = value.(V)
+	if !ok {
+		return ret, fmt.Errorf("%w: value type is %v (expect %v)", ErrTypeUnmatched,
+			reflect.TypeOf(value), retType)
+	}
+	return ret, nil
+}
+
+func mapLikeEntries(ml MapLike) []MapEntry {
+	result := []MapEntry{}
+	ml.Range(func(key, value any) bool {
+		result = append(result, MapEntry{Key: mapLikeValueOf(key), Value: mapLikeValueOf(value)})
+		return true
+	})
+	return result
+}
+
+// mapLikeValueOf gets reflect.Value of a key or a value of a MapLike, a nil is kept as
+// a nil interface value rather than an invalid value
+func mapLikeValueOf(x any) reflect.Value {
+	if x == nil {
+		return reflect.ValueOf(&x).Elem()
+	}
+	return reflect.ValueOf(x)
+}
@@ -0,0 +1,162 @@
+package rflutil
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// orderedMap a MapLike keeping the insertion order of keys
+type orderedMap struct {
+	keys   []any
+	values map[any]any
+}
+
+func (m *orderedMap) Load(key any) (any, bool) {
+	v, ok := m.values[key]
+	return v, ok
+}
+
+func (m *orderedMap) Store(key, value any) {
+	if m.values == nil {
+		m.values = map[any]any{}
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) Delete(key any) {
+	if _, ok := m.values[key]; ok {
+		delete(m.values, key)
+		for i := range m.keys {
+			if m.keys[i] == key {
+				m.keys = append(m.keys[:i], m.keys[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+func (m *orderedMap) Range(f func(key, value any) bool) {
+	for _, k := range m.keys {
+		if !f(k, m.values[k]) {
+			return
+		}
+	}
+}
+
+func Test_MapLike_syncMap(t *testing.T) {
+	t.Run("#1: pointer to sync.Map", func(t *testing.T) {
+		m := &sync.Map{}
+		assert.Nil(t, MapSet(valOf(m), "a", 1))
+		assert.Nil(t, MapSet(valOf(m), "b", 2))
+
+		n, err := MapLen(valOf(m))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, n)
+
+		v, err := MapGet[int](valOf(m), "a")
+		assert.Nil(t, err)
+		assert.Equal(t, 1, v)
+
+		keys, err := MapKeysSorted(valOf(m))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{"a", "b"}, valuesToAny(keys))
+
+		entries, err := MapEntriesSorted(valOf(m))
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(entries))
+		assert.Equal(t, 2, entries[1].Value.Interface())
+
+		assert.Nil(t, MapDelete(valOf(m), "a"))
+		_, err = MapGet[int](valOf(m), "a")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#2: addressable sync.Map field", func(t *testing.T) {
+		type Cache struct {
+			Items sync.Map
+		}
+		c := &Cache{}
+		itemsVal := reflect.ValueOf(c).Elem().Field(0)
+		assert.Nil(t, MapSet(itemsVal, 1, "x"))
+		v, ok := c.Items.Load(1)
+		assert.True(t, ok)
+		assert.Equal(t, "x", v)
+
+		n, err := MapLen(itemsVal)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("#3: empty sync.Map", func(t *testing.T) {
+		entries, err := MapEntries(valOf(&sync.Map{}))
+		assert.Nil(t, err)
+		assert.Equal(t, []MapEntry{}, entries)
+	})
+}
+
+func Test_MapLike_custom(t *testing.T) {
+	m := &orderedMap{}
+	var ml MapLike = m
+	assert.Nil(t, MapSet(valOf(&ml), "z", 1))
+	assert.Nil(t, MapSet(valOf(&ml), "a", 2))
+	assert.Nil(t, MapSet(valOf(&ml), "m", 3))
+
+	keys, err := MapKeys(valOf(m))
+	assert.Nil(t, err)
+	assert.Equal(t, []any{"z", "a", "m"}, valuesToAny(keys))
+
+	assert.Nil(t, MapDelete(valOf(m), "a"))
+	n, err := MapLen(valOf(m))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, n)
+
+	_, err = MapGet[string](valOf(m), "z")
+	assert.ErrorIs(t, err, ErrTypeUnmatched)
+}
+
+func Test_MapLike_nilValues(t *testing.T) {
+	m := &sync.Map{}
+	m.Store("a", nil)
+	m.Store(nil, 1)
+
+	v, err := MapGet[any](valOf(m), "a")
+	assert.Nil(t, err)
+	assert.Nil(t, v)
+	p, err := MapGet[*int](valOf(m), "a")
+	assert.Nil(t, err)
+	assert.Nil(t, p)
+	_, err = MapGet[int](valOf(m), "a")
+	assert.ErrorIs(t, err, ErrTypeUnmatched)
+
+	entries, err := MapEntriesSorted(valOf(m))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(entries))
+	for _, entry := range entries {
+		assert.True(t, entry.Key.IsValid())
+		assert.True(t, entry.Value.IsValid())
+	}
+	assert.Nil(t, entries[0].Key.Interface())
+	assert.Nil(t, entries[1].Value.Interface())
+}
+
+func Test_MapLike_failure(t *testing.T) {
+	t.Run("#1: nil sync.Map", func(t *testing.T) {
+		var m *sync.Map
+		_, err := MapLen(valOf(m))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: unaddressable sync.Map", func(t *testing.T) {
+		type Cache struct {
+			Items sync.Map
+		}
+		_, err := MapKeys(reflect.ValueOf(Cache{}).Field(0))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
@@ -0,0 +1,222 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MapMergeStrategy determines how slice values of the same key are merged by MapMerge
+type MapMergeStrategy int
+
+const (
+	// MapMergeReplace slices in the source map replace the ones in the destination map
+	MapMergeReplace MapMergeStrategy = iota
+	// MapMergeConcat slices in the source map are appended to the ones in the destination map
+	MapMergeConcat
+)
+
+// MapDiffResult paths of the keys which differ between 2 maps. A path of a nested key is
+// formed by joining the keys with dots, e.g. "a.b.c".
+type MapDiffResult struct {
+	Added   []string // keys found in the second map only
+	Removed []string // keys found in the first map only
+	Changed []string // keys found in both maps with different values
+}
+
+// MapMerge merges a source map into a destination map recursively.
+// When a key exists in both maps and both values are maps (including maps in interfaces such as
+// map[string]any), they are merged the same way. When both values are slices, they are merged
+// according to the strategy. Otherwise, the value in the source map overwrites the destination one.
+//
+// Keys and values of the source map are converted to the destination types (see ValueAs),
+// maps and slices of different types are converted item by item. Values taken from the source
+// map are deep-copied. Merging into a nil map results
+// in ErrValueInvalid unless option WithAutoInit is used and the map is settable.
+func MapMerge(dst, src reflect.Value, strategy MapMergeStrategy, opts ...Option) error {
+	srcEntries, err := MapEntries(src)
+	if err != nil {
+		return err
+	}
+	dstVal, err := mapForSet(dst, opts)
+	if err != nil {
+		return err
+	}
+	return mapMerge(dstVal, srcEntries, strategy, "")
+}
+
+func mapMerge(dst reflect.Value, srcEntries []MapEntry, strategy MapMergeStrategy, pathPrefix string) error {
+	// Values of the keys existing in both maps are merged in place when possible, the others
+	// are converted to the destination types and set one by one, as different source keys
+	// can be converted to the same destination key (e.g. int(1) and int64(1))
+	dstType := dst.Type()
+	for _, entry := range srcEntries {
+		path := pathPrefix + fmt.Sprintf("%v", entry.Key)
+		keyVal, err := valueConvert(entry.Key, dstType.Key(), true)
+		if err != nil {
+			return fmt.Errorf("key conversion failed: %w (path '%s')", err, path)
+		}
+		item, err := mapMergeEntry(dst.MapIndex(keyVal), entry.Value, dstType.Elem(), strategy, path)
+		if err != nil {
+			return err
+		}
+		if item.IsValid() {
+			mapExtendValue(dst, []MapEntry{{Key: keyVal, Value: item}}, false)
+		}
+	}
+	return nil
+}
+
+// mapMergeEntry merges a source value into a destination value (which is invalid when the key
+// doesn't exist in the destination map). Returns the value to set to the destination map,
+// or invalid value when the destination value is merged in place.
+func mapMergeEntry(dstItem, srcItem reflect.Value, targetType reflect.Type, strategy MapMergeStrategy,
+	path string) (reflect.Value, error) {
+	if dstItem.IsValid() {
+		merged, done, err := mapMergeItem(dstItem, srcItem, strategy, path)
+		if err != nil || done {
+			return reflect.Value{}, err
+		}
+		if merged.IsValid() {
+			return merged, nil
+		}
+	}
+	return mapMergeCopy(srcItem, targetType, strategy, path)
+}
+
+// mapMergeItem merges a source value into a destination value when they are maps or slices.
+// Nested maps are merged in place (done is true). Concatenated slices are returned to be set
+// to the parent map, as map values are not addressable. Returns invalid value and false if the
+// destination value should be overwritten by the source one.
+func mapMergeItem(dst, src reflect.Value, strategy MapMergeStrategy, path string) (
+	merged reflect.Value, done bool, err error) {
+	dstElem, srcElem := elemOfInterface(dst), elemOfInterface(src)
+	if !dstElem.IsValid() || !srcElem.IsValid() {
+		return reflect.Value{}, false, nil
+	}
+
+	switch {
+	case dstElem.Kind() == reflect.Map && srcElem.Kind() == reflect.Map && !dstElem.IsNil():
+		srcEntries, _ := MapEntries(srcElem)
+		return reflect.Value{}, true, mapMerge(dstElem, srcEntries, strategy, path+".")
+	case dstElem.Kind() == reflect.Slice && srcElem.Kind() == reflect.Slice && strategy == MapMergeConcat:
+		length := dstElem.Len()
+		result := reflect.MakeSlice(dstElem.Type(), length, length+srcElem.Len())
+		reflect.Copy(result, dstElem)
+		for i := 0; i < srcElem.Len(); i++ {
+			item, err := mapMergeCopy(srcElem.Index(i), dstElem.Type().Elem(), strategy,
+				fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return reflect.Value{}, false, err
+			}
+			result = reflect.Append(result, item)
+		}
+		return result, false, nil
+	}
+	return reflect.Value{}, false, nil
+}
+
+// mapMergeCopy makes a deep copy of a source value in the target type. Maps and slices of
+// different types are copied item by item, e.g. map[string]int can be copied as map[string]int64.
+func mapMergeCopy(v reflect.Value, targetType reflect.Type, strategy MapMergeStrategy, path string) (
+	reflect.Value, error) {
+	ret, err := valueConvert(deepCopyValue(v), targetType, true)
+	if err == nil {
+		return ret, nil
+	}
+
+	elem := elemOfInterface(v)
+	switch {
+	case elem.IsValid() && elem.Kind() == reflect.Map && targetType.Kind() == reflect.Map:
+		if elem.IsNil() {
+			return reflect.Zero(targetType), nil
+		}
+		ret = reflect.MakeMapWithSize(targetType, elem.Len())
+		entries, _ := MapEntries(elem)
+		if err = mapMerge(ret, entries, strategy, path+"."); err != nil {
+			return reflect.Value{}, err
+		}
+		return ret, nil
+	case elem.IsValid() && elem.Kind() == reflect.Slice && targetType.Kind() == reflect.Slice:
+		if elem.IsNil() {
+			return reflect.Zero(targetType), nil
+		}
+		ret = reflect.MakeSlice(targetType, elem.Len(), elem.Len())
+		for i := 0; i < elem.Len(); i++ {
+			item, err := mapMergeCopy(elem.Index(i), targetType.Elem(), strategy, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			ret.Index(i).Set(item)
+		}
+		return ret, nil
+	}
+	return reflect.Value{}, fmt.Errorf("value conversion failed: %w (path '%s')", err, path)
+}
+
+// MapDiff compares 2 maps recursively and returns paths of the added, removed, and changed keys.
+// Nested maps (including maps in interfaces such as map[string]any) are compared key by key,
+// other values are compared by reflect.DeepEqual. Keys are converted to the key type of the other map
+// for comparison. Paths are listed in the natural order of the keys.
+func MapDiff(a, b reflect.Value) (MapDiffResult, error) {
+	var result MapDiffResult
+	aVal := indirectValueTilRoot(a)
+	if !aVal.IsValid() || aVal.Kind() != reflect.Map {
+		return result, fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, a.Type())
+	}
+	bVal := indirectValueTilRoot(b)
+	if !bVal.IsValid() || bVal.Kind() != reflect.Map {
+		return result, fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, b.Type())
+	}
+	mapDiff(aVal, bVal, "", &result)
+	return result, nil
+}
+
+func mapDiff(a, b reflect.Value, pathPrefix string, result *MapDiffResult) {
+	aKeys, _ := MapKeysSorted(a)
+	for _, k := range aKeys {
+		path := pathPrefix + fmt.Sprintf("%v", k)
+		bItem := mapIndexConverted(b, k)
+		if !bItem.IsValid() {
+			result.Removed = append(result.Removed, path)
+			continue
+		}
+		aItem := a.MapIndex(k)
+		aElem, bElem := elemOfInterface(aItem), elemOfInterface(bItem)
+		if aElem.IsValid() && bElem.IsValid() && aElem.Kind() == reflect.Map && bElem.Kind() == reflect.Map {
+			mapDiff(aElem, bElem, path+".", result)
+			continue
+		}
+		if !reflect.DeepEqual(aItem.Interface(), bItem.Interface()) {
+			result.Changed = append(result.Changed, path)
+		}
+	}
+
+	bKeys, _ := MapKeysSorted(b)
+	for _, k := range bKeys {
+		if !mapIndexConverted(a, k).IsValid() {
+			result.Added = append(result.Added, pathPrefix+fmt.Sprintf("%v", k))
+		}
+	}
+}
+
+// mapIndexConverted gets the value of a key converted to the map key type, returns invalid value
+// if the key is not found or not convertible. Lossy conversions (e.g. 1.5 to 1) are considered
+// not convertible, so that matching keys of 2 maps doesn't depend on which map is the first.
+func mapIndexConverted(m, k reflect.Value) reflect.Value {
+	keyVal, err := valueConvert(k, m.Type().Key(), true)
+	if err != nil {
+		return reflect.Value{}
+	}
+	if back, err := valueConvert(keyVal, k.Type(), true); err != nil || back.Interface() != k.Interface() {
+		return reflect.Value{}
+	}
+	return m.MapIndex(keyVal)
+}
+
+// elemOfInterface returns the underlying value of an interface value
+func elemOfInterface(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return v
+}
@@ -0,0 +1,188 @@
+package rflutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MapMerge(t *testing.T) {
+	t.Run("#1: nested map[string]any", func(t *testing.T) {
+		dst := map[string]any{
+			"a": 1,
+			"b": map[string]any{"x": 1, "y": []any{1}},
+			"c": []int{1},
+		}
+		src := map[string]any{
+			"a": 2,
+			"b": map[string]any{"y": []any{2}, "z": 3},
+			"c": []int{2},
+			"d": "new",
+		}
+		err := MapMerge(valOf(dst), valOf(src), MapMergeReplace)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"a": 2,
+			"b": map[string]any{"x": 1, "y": []any{2}, "z": 3},
+			"c": []int{2},
+			"d": "new",
+		}, dst)
+	})
+
+	t.Run("#2: concat slices", func(t *testing.T) {
+		dst := map[string]any{
+			"b": map[string]any{"y": []any{1}},
+			"c": []int{1},
+			"s": "x",
+		}
+		src := map[string]any{
+			"b": map[string]any{"y": []any{2, "3"}},
+			"c": []int{2, 3},
+			"s": []int{1},
+		}
+		err := MapMerge(valOf(dst), valOf(src), MapMergeConcat)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"b": map[string]any{"y": []any{1, 2, "3"}},
+			"c": []int{1, 2, 3},
+			"s": []int{1},
+		}, dst)
+	})
+
+	t.Run("#3: typed maps", func(t *testing.T) {
+		type Key string
+		dst := map[Key]map[string][]int64{"a": {"x": {1}}, "b": nil}
+		src := map[string]map[string][]int{"a": {"x": {2}, "y": {3}}, "b": {"z": {4}}}
+		err := MapMerge(valOf(dst), valOf(src), MapMergeConcat)
+		assert.Nil(t, err)
+		assert.Equal(t, map[Key]map[string][]int64{
+			"a": {"x": {1, 2}, "y": {3}},
+			"b": {"z": {4}},
+		}, dst)
+	})
+
+	t.Run("#4: values are copied", func(t *testing.T) {
+		dst := map[string]any{}
+		nested := map[string]any{"x": 1}
+		err := MapMerge(valOf(dst), valOf(map[string]any{"n": nested}), MapMergeReplace)
+		assert.Nil(t, err)
+		err = MapMerge(valOf(dst), valOf(map[string]any{"n": map[string]any{"x": 2}}), MapMergeReplace)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"x": 1}, nested)
+		assert.Equal(t, map[string]any{"n": map[string]any{"x": 2}}, dst)
+	})
+
+	t.Run("#5: nil destination with auto init", func(t *testing.T) {
+		var dst map[string]int
+		err := MapMerge(valOf(&dst), valOf(map[string]int{"a": 1}), MapMergeReplace, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"a": 1}, dst)
+	})
+
+	t.Run("#6: source keys converted to the same key", func(t *testing.T) {
+		dst := map[int]any{}
+		err := MapMerge(valOf(dst), valOf(map[any]any{1: []int{1}, int64(1): []int{2}}), MapMergeConcat)
+		assert.Nil(t, err)
+		assert.Len(t, dst, 1)
+		assert.ElementsMatch(t, []int{1, 2}, dst[1])
+	})
+}
+
+func Test_MapMerge_failure(t *testing.T) {
+	t.Run("#1: input is not a map", func(t *testing.T) {
+		err := MapMerge(valOf(map[string]int{}), valOf("abc"), MapMergeReplace)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		err = MapMerge(valOf([]int{}), valOf(map[string]int{}), MapMergeReplace)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: nil destination", func(t *testing.T) {
+		var dst map[string]int
+		err := MapMerge(valOf(dst), valOf(map[string]int{"a": 1}), MapMergeReplace)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#3: key type unmatched", func(t *testing.T) {
+		err := MapMerge(valOf(map[int]int{}), valOf(map[string]int{"a": 1}), MapMergeReplace)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
+
+	t.Run("#4: value type unmatched", func(t *testing.T) {
+		dst := map[string]any{"a": map[string]int{"x": 1}}
+		err := MapMerge(valOf(dst), valOf(map[string]any{"a": map[string]any{"x": "s"}}), MapMergeReplace)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "path 'a.x'")
+
+		dst = map[string]any{"a": []int{1}}
+		err = MapMerge(valOf(dst), valOf(map[string]any{"a": []string{"s"}}), MapMergeConcat)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "path 'a[0]'")
+	})
+
+	t.Run("#5: numeric keys are not converted to string keys as runes", func(t *testing.T) {
+		dst := map[string]any{}
+		err := MapMerge(valOf(dst), valOf(map[int]any{1: "x"}), MapMergeReplace)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "key conversion failed")
+		assert.Equal(t, map[string]any{}, dst)
+	})
+}
+
+func Test_MapDiff(t *testing.T) {
+	t.Run("#1: no difference", func(t *testing.T) {
+		m := map[string]any{"a": 1, "b": map[string]any{"x": []int{1}}}
+		diff, err := MapDiff(valOf(m), valOf(map[string]any{"a": 1, "b": map[string]any{"x": []int{1}}}))
+		assert.Nil(t, err)
+		assert.Equal(t, MapDiffResult{}, diff)
+	})
+
+	t.Run("#2: nested differences", func(t *testing.T) {
+		a := map[string]any{
+			"a": 1,
+			"b": map[string]any{"x": 1, "y": 2, "n": map[string]any{"k": true}},
+			"c": "c",
+			"d": map[string]any{},
+		}
+		b := map[string]any{
+			"a": 2,
+			"b": map[string]any{"x": 1, "z": 3, "n": map[string]any{"k": false}},
+			"d": "d",
+			"e": []int{1},
+		}
+		diff, err := MapDiff(valOf(a), valOf(&b))
+		assert.Nil(t, err)
+		assert.Equal(t, MapDiffResult{
+			Added:   []string{"b.z", "e"},
+			Removed: []string{"b.y", "c"},
+			Changed: []string{"a", "b.n.k", "d"},
+		}, diff)
+	})
+
+	t.Run("#3: typed maps with different key types", func(t *testing.T) {
+		type Key string
+		a := map[string]map[int]int{"a": {1: 1, 2: 2}}
+		b := map[Key]map[int]int{"a": {1: 1, 2: 3}, "b": nil}
+		diff, err := MapDiff(valOf(a), valOf(b))
+		assert.Nil(t, err)
+		assert.Equal(t, MapDiffResult{Added: []string{"b"}, Changed: []string{"a.2"}}, diff)
+	})
+
+	t.Run("#4: keys not convertible are added or removed", func(t *testing.T) {
+		diff, err := MapDiff(valOf(map[int]int{65: 1}), valOf(map[string]int{"A": 1}))
+		assert.Nil(t, err)
+		assert.Equal(t, MapDiffResult{Added: []string{"A"}, Removed: []string{"65"}}, diff)
+
+		diff, err = MapDiff(valOf(map[float64]int{1.5: 1}), valOf(map[int]int{1: 1}))
+		assert.Nil(t, err)
+		assert.Equal(t, MapDiffResult{Added: []string{"1"}, Removed: []string{"1.5"}}, diff)
+	})
+}
+
+func Test_MapDiff_failure(t *testing.T) {
+	t.Run("#1: input is not a map", func(t *testing.T) {
+		_, err := MapDiff(valOf("abc"), valOf(map[string]int{}))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = MapDiff(valOf(map[string]int{}), valOf(123))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
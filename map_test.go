@@ -41,6 +41,12 @@ func Test_MapGet(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, uint(1), v)
 	})
+
+	t.Run("#2: success with key assignable to map key type", func(t *testing.T) {
+		v, err := MapGet[string](valOf(map[any]string{1: "a", "b": "c"}), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "a", v)
+	})
 }
 
 func Test_MapGet_failure(t *testing.T) {
@@ -83,6 +89,20 @@ func Test_MapSet(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, uint(44), m[4])
 	})
+
+	t.Run("#3: success with assignable key and value", func(t *testing.T) {
+		m := map[any]any{}
+		err := MapSet(valOf(m), 1, "a")
+		assert.Nil(t, err)
+		err = MapSet[string, any](valOf(m), "b", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, map[any]any{1: "a", "b": nil}, m)
+
+		m2 := map[string][]int{}
+		err = MapSet[string, any](valOf(m2), "a", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]int{"a": nil}, m2)
+	})
 }
 
 func Test_MapSet_failure(t *testing.T) {
@@ -100,6 +120,93 @@ func Test_MapSet_failure(t *testing.T) {
 		err := MapSet(valOf(map[int]uint{1: 1, 2: 2, 3: 3}), int64(1), uint(11))
 		assert.ErrorIs(t, err, ErrTypeUnmatched)
 	})
+
+	t.Run("#4: nil value for non-nillable type", func(t *testing.T) {
+		err := MapSet[int, any](valOf(map[int]uint{}), 1, nil)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
+}
+
+func Test_MapGetAs(t *testing.T) {
+	type Key string
+
+	t.Run("#1: key conversion", func(t *testing.T) {
+		v, err := MapGetAs[string](valOf(map[int64]string{1: "a"}), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "a", v)
+
+		v, err = MapGetAs[string](valOf(map[Key]string{"k": "a"}), "k")
+		assert.Nil(t, err)
+		assert.Equal(t, "a", v)
+	})
+
+	t.Run("#2: value conversion", func(t *testing.T) {
+		v, err := MapGetAs[float64](valOf(map[string]any{"k": 1}), "k")
+		assert.Nil(t, err)
+		assert.Equal(t, 1.0, v)
+	})
+
+	t.Run("#3: failure", func(t *testing.T) {
+		_, err := MapGetAs[string](valOf("abc"), 1)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = MapGetAs[string](valOf(map[int]string{1: "a"}), "1")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "key conversion failed")
+		_, err = MapGetAs[int](valOf(map[int]string{1: "a"}), 1)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "value conversion failed")
+		_, err = MapGetAs[string](valOf(map[int]string{1: "a"}), 2)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#4: numbers are not converted to strings as runes", func(t *testing.T) {
+		_, err := MapGetAs[int](valOf(map[string]int{"A": 7}), 65)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "key conversion failed")
+		_, err = MapGetAs[string](valOf(map[string]int{"A": 65}), "A")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "value conversion failed")
+	})
+}
+
+func Test_MapSetAs(t *testing.T) {
+	type Key string
+	type Value struct {
+		I int
+	}
+
+	t.Run("#1: key and value conversion", func(t *testing.T) {
+		m := map[int64]float32{}
+		err := MapSetAs(valOf(m), 1, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, map[int64]float32{1: 2}, m)
+
+		m2 := map[Key]*Value{}
+		err = MapSetAs(valOf(&m2), "k", &Value{I: 1})
+		assert.Nil(t, err)
+		err = MapSetAs(valOf(&m2), "k2", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, map[Key]*Value{"k": {I: 1}, "k2": nil}, m2)
+	})
+
+	t.Run("#2: failure", func(t *testing.T) {
+		err := MapSetAs(valOf([]int{}), 1, 1)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		err = MapSetAs(valOf(map[int]int{}), "1", 1)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "key conversion failed")
+		err = MapSetAs(valOf(map[int]int{}), 1, "1")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "value conversion failed")
+		err = MapSetAs(valOf(map[int]int{}), 1, nil)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		err = MapSetAs(valOf(map[string]int{}), 65, 1)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "key conversion failed")
+		err = MapSetAs(valOf(map[string]string{}), "A", 65)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "value conversion failed")
+	})
 }
 
 func Test_MapDelete(t *testing.T) {
@@ -200,3 +307,94 @@ func Test_MapEntries_failure(t *testing.T) {
 		assert.Nil(t, entries)
 	})
 }
+
+func Test_MapKeysSorted(t *testing.T) {
+	keysOf := func(keys []reflect.Value) []any {
+		result := make([]any, 0, len(keys))
+		for _, k := range keys {
+			result = append(result, k.Interface())
+		}
+		return result
+	}
+
+	t.Run("#1: basic types", func(t *testing.T) {
+		keys, err := MapKeysSorted(valOf(map[string]int{"b": 1, "c": 2, "a": 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{"a", "b", "c"}, keysOf(keys))
+
+		keys, err = MapKeysSorted(valOf(map[int8]int{3: 1, -1: 2, 0: 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{int8(-1), int8(0), int8(3)}, keysOf(keys))
+
+		keys, err = MapKeysSorted(valOf(map[uint]int{3: 1, 1: 2, 2: 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{uint(1), uint(2), uint(3)}, keysOf(keys))
+
+		keys, err = MapKeysSorted(valOf(map[float64]int{3.5: 1, -1.5: 2, 2: 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{-1.5, 2.0, 3.5}, keysOf(keys))
+
+		keys, err = MapKeysSorted(valOf(map[bool]int{true: 1, false: 2}))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{false, true}, keysOf(keys))
+	})
+
+	t.Run("#2: struct and array keys", func(t *testing.T) {
+		type K struct {
+			A string
+			B int
+		}
+		keys, err := MapKeysSorted(valOf(map[K]int{{"b", 1}: 1, {"a", 2}: 2, {"a", 1}: 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{K{"a", 1}, K{"a", 2}, K{"b", 1}}, keysOf(keys))
+
+		keys, err = MapKeysSorted(valOf(map[[2]int]int{{2, 1}: 1, {1, 2}: 2, {1, 1}: 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{[2]int{1, 1}, [2]int{1, 2}, [2]int{2, 1}}, keysOf(keys))
+	})
+
+	t.Run("#3: mixed kinds in interface keys", func(t *testing.T) {
+		type Str string
+		m := map[any]int{"b": 1, 2: 2, "a": 3, 1: 4, nil: 5, true: 6, Str("a"): 7, 1.5: 8}
+		keys, err := MapKeysSorted(valOf(m))
+		assert.Nil(t, err)
+		assert.Equal(t, []any{nil, true, 1, 2, 1.5, Str("a"), "a", "b"}, keysOf(keys))
+	})
+
+	t.Run("#4: custom less function", func(t *testing.T) {
+		keys, err := MapKeysSortedFunc(valOf(map[string]int{"bb": 1, "c": 2, "aaa": 3}),
+			func(k1, k2 reflect.Value) bool { return k1.Len() < k2.Len() })
+		assert.Nil(t, err)
+		assert.Equal(t, []any{"c", "bb", "aaa"}, keysOf(keys))
+	})
+
+	t.Run("#5: input is not map", func(t *testing.T) {
+		_, err := MapKeysSorted(valOf([]int{}))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_MapEntriesSorted(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		entries, err := MapEntriesSorted(valOf(map[string]int{"b": 1, "c": 2, "a": 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(entries))
+		assert.Equal(t, "a", entries[0].Key.Interface())
+		assert.Equal(t, 3, entries[0].Value.Interface())
+		assert.Equal(t, "c", entries[2].Key.Interface())
+		assert.Equal(t, 2, entries[2].Value.Interface())
+	})
+
+	t.Run("#2: custom less function", func(t *testing.T) {
+		entries, err := MapEntriesSortedFunc(valOf(map[int]int{1: 1, 2: 2, 3: 3}),
+			func(k1, k2 reflect.Value) bool { return k1.Int() > k2.Int() })
+		assert.Nil(t, err)
+		assert.Equal(t, 3, entries[0].Key.Interface())
+		assert.Equal(t, 1, entries[2].Key.Interface())
+	})
+
+	t.Run("#3: input is not map", func(t *testing.T) {
+		_, err := MapEntriesSorted(valOf("abc"))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
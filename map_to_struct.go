@@ -0,0 +1,312 @@
+package rflutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	polyTagName = "poly"
+)
+
+// MapToStruct populates a struct from a map, the reverse of StructToMap. Input v must be a pointer
+// to a struct. Map keys are matched to the fields by the names in the custom tag (or the field names
+// when the tag is absent), exactly first, then case-insensitively. Multiple keys matching a field
+// case-insensitively (e.g. "Name" and "NAME" for the field "name") result in ErrDuplicated.
+// Embedded structs without tag names are populated from the same map. Unknown keys are ignored.
+//
+// Values are converted to the field types the same way as ValueAs does. Nested maps populate nested
+// structs, and slices and maps are populated item by item. Nil pointers are allocated as needed.
+//
+// Fields of interface types (or slices, arrays, and maps of them) having the tag `poly:"<key>"`
+// are populated from maps containing the discriminator key, for example:
+//
+//	type Drawing struct {
+//		Shape  Shape   `poly:"kind"`
+//		Shapes []Shape `poly:"kind"`
+//	}
+//
+// populating Shape from map{"kind": "circle", "r": 2} creates the type registered with the name
+// "circle" in the registry passed via option WithTypeRegistry. The value is stored as is if it
+// implements the interface, otherwise a pointer to it is stored.
+func MapToStruct(m reflect.Value, v reflect.Value, customTag string, opts ...Option) error {
+	var customTags []string
+	if customTag != "" {
+		customTags = []string{customTag}
+	}
+	return MapToStructByTags(m, v, customTags, opts...)
+}
+
+// MapToStructByTags populates a struct from a map with using a chain of tags to determine the keys
+// of the fields. For each field, the first tag present in the chain is used (see ParseTagChain).
+func MapToStructByTags(m reflect.Value, v reflect.Value, customTags []string, opts ...Option) error {
+	entries, err := MapEntries(m)
+	if err != nil {
+		return err
+	}
+	val := indirectValueTilRootEx(v, true)
+	if !val.IsValid() || val.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: require struct type (got %v)", ErrTypeInvalid, v.Type())
+	}
+	if !val.CanSet() {
+		return fmt.Errorf("%w: require pointer to struct (got %v)", ErrValueUnsettable, v.Type())
+	}
+
+	d := &mapDecoder{tagNames: customTags, opts: newOptions(opts)}
+	_, err = d.decodeStruct(mapEntriesByKey(entries), val, "")
+	return err
+}
+
+type mapDecoder struct {
+	tagNames []string
+	opts     *options
+}
+
+// decodeStruct populates a struct from the entries, returns the number of the populated fields
+func (d *mapDecoder) decodeStruct(entries map[string]reflect.Value, dst reflect.Value, path string) (int, error) {
+	typ := dst.Type()
+	count := 0
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		var tag *Tag
+		if len(d.tagNames) > 0 {
+			var err error
+			tag, err = ParseTagChain(&sf, d.tagNames, ",")
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return count, err
+			}
+		}
+		if tag != nil && tag.Ignored {
+			continue
+		}
+
+		field := dst.Field(i)
+		if sf.Anonymous && (tag == nil || tag.Name == "") && indirectTypeTilRoot(sf.Type).Kind() == reflect.Struct {
+			n, err := d.decodeEmbedded(entries, field, path)
+			count += n
+			if err != nil {
+				return count, err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		key := sf.Name
+		if tag != nil && tag.Name != "" {
+			key = tag.Name
+		}
+		srcVal, ok, err := mapEntryByKey(entries, key)
+		if err != nil {
+			return count, fmt.Errorf("%w (path '%s')", err, joinFieldPath(path, sf.Name))
+		}
+		if !ok {
+			continue
+		}
+		if err := d.decode(srcVal, field, sf.Tag.Get(polyTagName), joinFieldPath(path, sf.Name)); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// decodeEmbedded populates an embedded struct. A nil embedded pointer is allocated only when
+// some of its fields are populated.
+func (d *mapDecoder) decodeEmbedded(entries map[string]reflect.Value, field reflect.Value, path string) (int, error) {
+	if field.Kind() != reflect.Pointer {
+		return d.decodeStruct(entries, field, path)
+	}
+	if !field.IsNil() {
+		return d.decodeEmbedded(entries, field.Elem(), path)
+	}
+	if !field.CanSet() {
+		return 0, nil
+	}
+	ptr := reflect.New(field.Type().Elem())
+	count, err := d.decodeEmbedded(entries, ptr.Elem(), path)
+	if count > 0 && err == nil {
+		field.Set(ptr)
+	}
+	return count, err
+}
+
+//nolint:gocognit,gocyclo
+func (d *mapDecoder) decode(src, dst reflect.Value, poly, path string) error {
+	src = elemOfInterface(src)
+	if !src.IsValid() {
+		// Nil only resets nillable values, the same as encoding/json does for null
+		if isKindIn(dst.Kind(), reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice) {
+			dst.Set(reflect.Zero(dst.Type()))
+		}
+		return nil
+	}
+	if poly != "" && dst.Kind() == reflect.Interface {
+		return d.decodePoly(src, dst, poly, path)
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+
+	switch dst.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if dst.IsNil() {
+			ptr := reflect.New(dst.Type().Elem())
+			if err := d.decode(src, ptr.Elem(), poly, path); err != nil {
+				return err
+			}
+			dst.Set(ptr)
+			return nil
+		}
+		return d.decode(src, dst.Elem(), poly, path)
+	case reflect.Struct:
+		if src.Kind() == reflect.Map {
+			entries, _ := MapEntries(src)
+			_, err := d.decodeStruct(mapEntriesByKey(entries), dst, path)
+			return err
+		}
+	case reflect.Slice, reflect.Array:
+		if src.Kind() == reflect.Slice || src.Kind() == reflect.Array {
+			result := dst
+			if dst.Kind() == reflect.Slice {
+				result = reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+			}
+			for i := 0; i < src.Len() && i < result.Len(); i++ {
+				if err := d.decode(src.Index(i), result.Index(i), poly, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			dst.Set(result)
+			return nil
+		}
+	case reflect.Map:
+		if src.Kind() == reflect.Map {
+			dstType := dst.Type()
+			result := reflect.MakeMapWithSize(dstType, src.Len())
+			iter := src.MapRange()
+			for iter.Next() {
+				itemPath := fmt.Sprintf("%s[%v]", path, iter.Key())
+				key, err := valueConvert(iter.Key(), dstType.Key(), false)
+				if err != nil {
+					return fmt.Errorf("%w (path '%s')", err, itemPath)
+				}
+				item := reflect.New(dstType.Elem()).Elem()
+				if err = d.decode(iter.Value(), item, poly, itemPath); err != nil {
+					return err
+				}
+				result.SetMapIndex(key, item)
+			}
+			dst.Set(result)
+			return nil
+		}
+	}
+
+	// Numbers are convertible to strings as runes, that is not wanted here
+	if dst.Kind() == reflect.String && isKindIn(src.Kind(), reflect.Int, reflect.Int8, reflect.Int16,
+		reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr) {
+		return fmt.Errorf("%w: value type is %v (expect %v) (path '%s')", ErrTypeUnmatched,
+			src.Type(), dst.Type(), path)
+	}
+	converted, err := valueConvert(src, dst.Type(), false)
+	if err != nil {
+		return fmt.Errorf("%w (path '%s')", err, path)
+	}
+	dst.Set(converted)
+	return nil
+}
+
+// decodePoly populates an interface value from a map having the discriminator key
+func (d *mapDecoder) decodePoly(src, dst reflect.Value, discriminatorKey, path string) error {
+	if src.Kind() != reflect.Map {
+		if src.Type().AssignableTo(dst.Type()) {
+			dst.Set(src)
+			return nil
+		}
+		return fmt.Errorf("%w: value type is %v (expect map) (path '%s')", ErrTypeUnmatched, src.Type(), path)
+	}
+	if d.opts.typeRegistry == nil {
+		return fmt.Errorf("%w: type registry is required for tag '%s' (path '%s')",
+			ErrValueInvalid, polyTagName, path)
+	}
+
+	entries, _ := MapEntries(src)
+	discriminator, ok, err := mapEntryByKey(mapEntriesByKey(entries), discriminatorKey)
+	if err != nil {
+		return fmt.Errorf("%w (path '%s')", err, path)
+	}
+	if !ok {
+		return fmt.Errorf("%w: discriminator key '%s' (path '%s')", ErrNotFound, discriminatorKey, path)
+	}
+	discriminator = elemOfInterface(discriminator)
+	if !discriminator.IsValid() || discriminator.Kind() != reflect.String {
+		return fmt.Errorf("%w: discriminator '%s' must be a string (path '%s')",
+			ErrValueInvalid, discriminatorKey, path)
+	}
+	typ, ok := d.opts.typeRegistry.Lookup(discriminator.String())
+	if !ok {
+		return fmt.Errorf("%w: type '%s' is not registered (path '%s')", ErrNotFound, discriminator.String(), path)
+	}
+
+	ifaceType := dst.Type()
+	usePtr := !typ.Implements(ifaceType)
+	if usePtr && !reflect.PointerTo(typ).Implements(ifaceType) {
+		return fmt.Errorf("%w: type %v doesn't implement %v (path '%s')", ErrTypeUnmatched, typ, ifaceType, path)
+	}
+	ptr := reflect.New(typ)
+	if err := d.decode(src, ptr.Elem(), "", path); err != nil {
+		return err
+	}
+	if usePtr {
+		dst.Set(ptr)
+	} else {
+		dst.Set(ptr.Elem())
+	}
+	return nil
+}
+
+// mapEntriesByKey indexes map entries by string keys, entries of other key types are skipped
+func mapEntriesByKey(entries []MapEntry) map[string]reflect.Value {
+	result := make(map[string]reflect.Value, len(entries))
+	for _, entry := range entries {
+		if key := elemOfInterface(entry.Key); key.IsValid() && key.Kind() == reflect.String {
+			result[key.String()] = entry.Value
+		}
+	}
+	return result
+}
+
+// mapEntryByKey finds a value by key exactly first, then case-insensitively.
+// Returns ErrDuplicated if multiple keys match case-insensitively.
+func mapEntryByKey(entries map[string]reflect.Value, key string) (reflect.Value, bool, error) {
+	if val, ok := entries[key]; ok {
+		return val, true, nil
+	}
+	var matchedKeys []string
+	for k := range entries {
+		if strings.EqualFold(k, key) {
+			matchedKeys = append(matchedKeys, k)
+		}
+	}
+	switch len(matchedKeys) {
+	case 0:
+		return reflect.Value{}, false, nil
+	case 1:
+		return entries[matchedKeys[0]], true, nil
+	}
+	sort.Strings(matchedKeys)
+	return reflect.Value{}, false, fmt.Errorf("%w: keys %q match '%s' case-insensitively",
+		ErrDuplicated, matchedKeys, key)
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,265 @@
+package rflutil
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type polyTestShape interface {
+	Area() float64
+}
+
+type polyTestCircle struct {
+	R float64 `json:"r"`
+}
+
+func (c polyTestCircle) Area() float64 {
+	return math.Pi * c.R * c.R
+}
+
+type polyTestRect struct {
+	W float64 `json:"w"`
+	H float64 `json:"h"`
+}
+
+func (r *polyTestRect) Area() float64 {
+	return r.W * r.H
+}
+
+func polyTestRegistry() *TypeRegistry {
+	reg := NewTypeRegistry()
+	_ = reg.Register(reflect.TypeOf(polyTestCircle{}), "circle")
+	_ = reg.Register(reflect.TypeOf(polyTestRect{}), "rect")
+	_ = reg.Register(reflect.TypeOf(time.Time{}), "time")
+	return reg
+}
+
+func Test_MapToStruct(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Base struct {
+		ID int64 `json:"id"`
+	}
+	type User struct {
+		*Base
+		Name    string            `json:"name"`
+		Age     uint8             `json:"age"`
+		Home    *Address          `json:"home"`
+		Offices []Address         `json:"offices"`
+		Scores  map[string]int    `json:"scores"`
+		Extra   any               `json:"extra"`
+		Skipped string            `json:"-"`
+		Labels  map[string]string `json:"labels"`
+		secret  string
+	}
+
+	t.Run("#1: nested values", func(t *testing.T) {
+		m := map[string]any{
+			"id":      1,
+			"NAME":    "abc",
+			"age":     30.0,
+			"home":    map[string]any{"city": "Hanoi"},
+			"offices": []any{map[string]any{"city": "A"}, map[string]any{"city": "B"}},
+			"scores":  map[string]any{"x": 1.0},
+			"extra":   []any{1, "2"},
+			"Skipped": "x",
+			"labels":  nil,
+			"secret":  "x",
+			"unknown": 1,
+		}
+		u := User{Labels: map[string]string{}}
+		err := MapToStruct(valOf(m), valOf(&u), "json")
+		assert.Nil(t, err)
+		assert.Equal(t, User{
+			Base:    &Base{ID: 1},
+			Name:    "abc",
+			Age:     30,
+			Home:    &Address{City: "Hanoi"},
+			Offices: []Address{{City: "A"}, {City: "B"}},
+			Scores:  map[string]int{"x": 1},
+			Extra:   []any{1, "2"},
+		}, u)
+	})
+
+	t.Run("#2: round trip with StructToMap", func(t *testing.T) {
+		u := User{Name: "abc", Home: &Address{City: "X"}}
+		m, err := StructToMap(valOf(u), "json", true)
+		assert.Nil(t, err)
+		var u2 User
+		err = MapToStruct(valOf(m), valOf(&u2), "json")
+		assert.Nil(t, err)
+		assert.Equal(t, u, u2)
+	})
+
+	t.Run("#3: embedded pointer not allocated if no fields populated", func(t *testing.T) {
+		var u User
+		err := MapToStruct(valOf(map[string]any{"name": "abc"}), valOf(&u), "json")
+		assert.Nil(t, err)
+		assert.Nil(t, u.Base)
+	})
+
+	t.Run("#4: no tag", func(t *testing.T) {
+		var a Address
+		err := MapToStruct(valOf(map[string]string{"City": "X"}), valOf(&a), "")
+		assert.Nil(t, err)
+		assert.Equal(t, "X", a.City)
+	})
+}
+
+func Test_MapToStructByTags(t *testing.T) {
+	type Item struct {
+		ID   int    `mapstructure:"item_id" json:"id"`
+		Name string `json:"name" yaml:"title"`
+		Note string `yaml:"note"`
+		Skip string `mapstructure:"-" json:"skip"`
+	}
+	m := map[string]any{"item_id": 1, "id": 2, "name": "a", "title": "b", "note": "c", "skip": "d"}
+	var item Item
+	err := MapToStructByTags(valOf(m), valOf(&item), []string{"mapstructure", "json", "yaml"})
+	assert.Nil(t, err)
+	assert.Equal(t, Item{ID: 1, Name: "a", Note: "c"}, item)
+
+	// Round trip with StructToMapByTags
+	out, err := StructToMapByTags(valOf(item), []string{"mapstructure", "json", "yaml"}, true)
+	assert.Nil(t, err)
+	var item2 Item
+	err = MapToStructByTags(valOf(out), valOf(&item2), []string{"mapstructure", "json", "yaml"})
+	assert.Nil(t, err)
+	assert.Equal(t, item, item2)
+}
+
+func Test_MapToStruct_poly(t *testing.T) {
+	type Drawing struct {
+		Shape   polyTestShape            `json:"shape" poly:"kind"`
+		Shapes  []polyTestShape          `json:"shapes" poly:"kind"`
+		ByName  map[string]polyTestShape `json:"by_name" poly:"kind"`
+		Default polyTestShape            `json:"default" poly:"kind"`
+		Any     any                      `json:"any" poly:"type"`
+	}
+
+	t.Run("#1: interface fields", func(t *testing.T) {
+		m := map[string]any{
+			"shape": map[string]any{"kind": "circle", "r": 2},
+			"shapes": []any{
+				map[string]any{"kind": "rect", "w": 2, "h": 3},
+				map[string]any{"kind": "circle", "r": 1},
+			},
+			"by_name": map[string]any{"a": map[string]any{"kind": "rect", "w": 1, "h": 1}},
+			"default": nil,
+			"any":     map[string]any{"type": "circle", "r": 3},
+		}
+		var d Drawing
+		err := MapToStruct(valOf(m), valOf(&d), "json", WithTypeRegistry(polyTestRegistry()))
+		assert.Nil(t, err)
+		assert.Equal(t, Drawing{
+			Shape:  polyTestCircle{R: 2},
+			Shapes: []polyTestShape{&polyTestRect{W: 2, H: 3}, polyTestCircle{R: 1}},
+			ByName: map[string]polyTestShape{"a": &polyTestRect{W: 1, H: 1}},
+			Any:    polyTestCircle{R: 3},
+		}, d)
+		assert.Equal(t, 6.0, d.Shapes[0].Area())
+	})
+
+	t.Run("#2: decoded JSON", func(t *testing.T) {
+		var m map[string]any
+		err := json.Unmarshal([]byte(`{"shape":{"kind":"rect","w":2,"h":2},"shapes":[{"kind":"circle","r":1}]}`), &m)
+		assert.Nil(t, err)
+		var d Drawing
+		err = MapToStruct(valOf(m), valOf(&d), "json", WithTypeRegistry(polyTestRegistry()))
+		assert.Nil(t, err)
+		assert.Equal(t, &polyTestRect{W: 2, H: 2}, d.Shape)
+		assert.Equal(t, []polyTestShape{polyTestCircle{R: 1}}, d.Shapes)
+	})
+
+	t.Run("#3: concrete value is assigned as is", func(t *testing.T) {
+		var d Drawing
+		err := MapToStruct(valOf(map[string]any{"shape": polyTestCircle{R: 1}}), valOf(&d), "json")
+		assert.Nil(t, err)
+		assert.Equal(t, polyTestCircle{R: 1}, d.Shape)
+	})
+}
+
+func Test_MapToStruct_failure(t *testing.T) {
+	type SS struct {
+		I     int           `json:"i"`
+		S     string        `json:"s"`
+		Shape polyTestShape `json:"shape" poly:"kind"`
+		Plain polyTestShape `json:"plain"`
+	}
+
+	t.Run("#1: invalid inputs", func(t *testing.T) {
+		err := MapToStruct(valOf("abc"), valOf(&SS{}), "json")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		err = MapToStruct(valOf(map[string]any{}), valOf(&[]int{}), "json")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		err = MapToStruct(valOf(map[string]any{}), valOf(SS{}), "json")
+		assert.ErrorIs(t, err, ErrValueUnsettable)
+	})
+
+	t.Run("#2: type unmatched", func(t *testing.T) {
+		err := MapToStruct(valOf(map[string]any{"i": "1"}), valOf(&SS{}), "json")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.ErrorContains(t, err, "path 'I'")
+		err = MapToStruct(valOf(map[string]any{"s": 65}), valOf(&SS{}), "json")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
+
+	t.Run("#3: interface field without poly tag", func(t *testing.T) {
+		err := MapToStruct(valOf(map[string]any{"plain": map[string]any{"kind": "circle"}}), valOf(&SS{}), "json",
+			WithTypeRegistry(polyTestRegistry()))
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
+
+	t.Run("#4: poly errors", func(t *testing.T) {
+		reg := WithTypeRegistry(polyTestRegistry())
+		m := map[string]any{"shape": map[string]any{"kind": "circle"}}
+		err := MapToStruct(valOf(m), valOf(&SS{}), "json")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+
+		m = map[string]any{"shape": map[string]any{"r": 1}}
+		err = MapToStruct(valOf(m), valOf(&SS{}), "json", reg)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		m = map[string]any{"shape": map[string]any{"kind": "square"}}
+		err = MapToStruct(valOf(m), valOf(&SS{}), "json", reg)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		m = map[string]any{"shape": map[string]any{"kind": 1}}
+		err = MapToStruct(valOf(m), valOf(&SS{}), "json", reg)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+
+		m = map[string]any{"shape": map[string]any{"kind": "time"}}
+		err = MapToStruct(valOf(m), valOf(&SS{}), "json", reg)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+
+		m = map[string]any{"shape": 1}
+		err = MapToStruct(valOf(m), valOf(&SS{}), "json", reg)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+
+		m = map[string]any{"shape": map[string]any{"Kind": "circle", "KIND": "circle"}}
+		err = MapToStruct(valOf(m), valOf(&SS{}), "json", reg)
+		assert.ErrorIs(t, err, ErrDuplicated)
+	})
+
+	t.Run("#5: ambiguous case-insensitive keys", func(t *testing.T) {
+		type Item struct {
+			Name string `json:"name"`
+		}
+		var item Item
+		err := MapToStruct(valOf(map[string]any{"Name": "a", "NAME": "b"}), valOf(&item), "json")
+		assert.ErrorIs(t, err, ErrDuplicated)
+		assert.ErrorContains(t, err, `keys ["NAME" "Name"] match 'name'`)
+		assert.ErrorContains(t, err, "path 'Name'")
+
+		// The exact match takes precedence
+		err = MapToStruct(valOf(map[string]any{"Name": "a", "NAME": "b", "name": "c"}), valOf(&item), "json")
+		assert.Nil(t, err)
+		assert.Equal(t, "c", item.Name)
+	})
+}
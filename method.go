@@ -0,0 +1,129 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MethodInfo information of a method
+type MethodInfo struct {
+	Name            string
+	Type            reflect.Type // func type of the method without the receiver
+	Signature       string       // e.g. "Add(int, ...string) (int, error)"
+	PointerReceiver bool         // true if only the pointer type has the method
+}
+
+// ListMethods lists the exported methods of a type including the ones promoted from embedded fields.
+// Pointers are dereferenced to the root type, the methods of the pointer receiver are included
+// when includePointerReceiver is true. If the input is an interface type (e.g. a nil pointer to
+// an interface), the methods of the interface are listed. Methods are sorted by names.
+func ListMethods(v reflect.Value, includePointerReceiver bool) ([]MethodInfo, error) {
+	typ, err := methodOwnerType(v)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]MethodInfo, 0, typ.NumMethod())
+	isInterface := typ.Kind() == reflect.Interface
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if m.IsExported() {
+			result = append(result, newMethodInfo(&m, !isInterface, false))
+		}
+	}
+	if !includePointerReceiver || isInterface {
+		return result, nil
+	}
+
+	ptrType := reflect.PointerTo(typ)
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		m := ptrType.Method(i)
+		if _, found := typ.MethodByName(m.Name); found || !m.IsExported() {
+			continue
+		}
+		result = append(result, newMethodInfo(&m, true, true))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// Implements checks whether a type implements an interface. Pointers of the input are dereferenced
+// to the root type, pointerOnly is true if only the pointer type implements the interface.
+// ifaceType can be an interface type or a pointer to an interface type.
+func Implements(v reflect.Value, ifaceType reflect.Type) (implemented bool, pointerOnly bool, err error) {
+	iface := indirectTypeTilRoot(ifaceType)
+	if iface.Kind() != reflect.Interface {
+		return false, false, fmt.Errorf("%w: require interface type (got %v)", ErrTypeInvalid, ifaceType)
+	}
+	typ, err := methodOwnerType(v)
+	if err != nil {
+		return false, false, err
+	}
+	if typ.Implements(iface) {
+		return true, false, nil
+	}
+	if typ.Kind() != reflect.Interface && reflect.PointerTo(typ).Implements(iface) {
+		return true, true, nil
+	}
+	return false, false, nil
+}
+
+// MissingMethods lists the methods of an interface which are absent in the method set of a type.
+// A method having a different signature is considered absent. The result contains the method
+// signatures required by the interface, e.g. "Read([]uint8) (int, error)".
+// ifaceType can be an interface type or a pointer to an interface type.
+func MissingMethods(t reflect.Type, ifaceType reflect.Type) ([]string, error) {
+	iface := indirectTypeTilRoot(ifaceType)
+	if iface.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("%w: require interface type (got %v)", ErrTypeInvalid, ifaceType)
+	}
+
+	var result []string
+	for i := 0; i < iface.NumMethod(); i++ {
+		im := iface.Method(i)
+		required := newMethodInfo(&im, false, false)
+		if m, found := t.MethodByName(im.Name); found {
+			if newMethodInfo(&m, t.Kind() != reflect.Interface, false).Type == required.Type {
+				continue
+			}
+		}
+		result = append(result, required.Signature)
+	}
+	return result, nil
+}
+
+// methodOwnerType gets the type to list methods from a value
+func methodOwnerType(v reflect.Value) (reflect.Type, error) {
+	if !v.IsValid() {
+		return nil, fmt.Errorf("%w: value is invalid", ErrTypeInvalid)
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	return indirectTypeTilRoot(v.Type()), nil
+}
+
+func newMethodInfo(m *reflect.Method, hasReceiver bool, pointerReceiver bool) MethodInfo {
+	funcType := m.Type
+	if hasReceiver {
+		in := make([]reflect.Type, 0, funcType.NumIn()-1)
+		for i := 1; i < funcType.NumIn(); i++ {
+			in = append(in, funcType.In(i))
+		}
+		out := make([]reflect.Type, 0, funcType.NumOut())
+		for i := 0; i < funcType.NumOut(); i++ {
+			out = append(out, funcType.Out(i))
+		}
+		funcType = reflect.FuncOf(in, out, funcType.IsVariadic())
+	}
+	return MethodInfo{
+		Name:            m.Name,
+		Type:            funcType,
+		Signature:       m.Name + strings.TrimPrefix(funcType.String(), "func"),
+		PointerReceiver: pointerReceiver,
+	}
+}
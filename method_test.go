@@ -0,0 +1,170 @@
+package rflutil
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type methodTestBase struct{}
+
+func (methodTestBase) ID() int { return 0 }
+
+func (*methodTestBase) SetID(int) {}
+
+type methodTestHandler struct {
+	methodTestBase
+}
+
+func (methodTestHandler) Name() string { return "" }
+
+func (*methodTestHandler) Handle(string, ...any) error { return nil }
+
+func (methodTestHandler) private() {} //nolint:unused
+
+type methodTestHandlerIface interface {
+	Name() string
+	Handle(string, ...any) error
+}
+
+func methodNames(methods []MethodInfo) []string {
+	names := make([]string, 0, len(methods))
+	for _, m := range methods {
+		names = append(names, m.Name)
+	}
+	return names
+}
+
+func Test_ListMethods(t *testing.T) {
+	t.Run("#1: value receiver only", func(t *testing.T) {
+		methods, err := ListMethods(valOf(methodTestHandler{}), false)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ID", "Name"}, methodNames(methods))
+		assert.Equal(t, "Name() string", methods[1].Signature)
+		assert.Equal(t, reflect.TypeOf(func() string { return "" }), methods[1].Type)
+		assert.False(t, methods[1].PointerReceiver)
+	})
+
+	t.Run("#2: include pointer receiver", func(t *testing.T) {
+		methods, err := ListMethods(valOf(&methodTestHandler{}), true)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Handle", "ID", "Name", "SetID"}, methodNames(methods))
+		assert.Equal(t, "Handle(string, ...interface {}) error", methods[0].Signature)
+		assert.True(t, methods[0].PointerReceiver)
+		assert.False(t, methods[1].PointerReceiver)
+		assert.True(t, methods[3].PointerReceiver)
+	})
+
+	t.Run("#3: embedded pointer promotes methods to value type", func(t *testing.T) {
+		type Handler2 struct {
+			*methodTestBase
+		}
+		methods, err := ListMethods(valOf(Handler2{}), true)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ID", "SetID"}, methodNames(methods))
+		assert.False(t, methods[1].PointerReceiver)
+	})
+
+	t.Run("#4: interface type", func(t *testing.T) {
+		methods, err := ListMethods(valOf((*io.ReadCloser)(nil)), true)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Close", "Read"}, methodNames(methods))
+		assert.Equal(t, "Read([]uint8) (int, error)", methods[1].Signature)
+	})
+
+	t.Run("#5: value in interface", func(t *testing.T) {
+		var s fmt.Stringer = reflect.TypeOf(0)
+		methods, err := ListMethods(reflect.ValueOf(&s).Elem(), false)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(methods))
+
+		methods, err = ListMethods(reflect.ValueOf(&s).Elem(), true)
+		assert.Nil(t, err)
+		assert.Contains(t, methodNames(methods), "String")
+	})
+}
+
+func Test_ListMethods_failure(t *testing.T) {
+	_, err := ListMethods(reflect.Value{}, false)
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+}
+
+func Test_Implements(t *testing.T) {
+	ifaceType := reflect.TypeOf((*methodTestHandlerIface)(nil))
+
+	t.Run("#1: pointer only", func(t *testing.T) {
+		ok, pointerOnly, err := Implements(valOf(methodTestHandler{}), ifaceType)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.True(t, pointerOnly)
+
+		ok, pointerOnly, err = Implements(valOf(&methodTestHandler{}), ifaceType.Elem())
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.True(t, pointerOnly)
+	})
+
+	t.Run("#2: value implements", func(t *testing.T) {
+		ok, pointerOnly, err := Implements(valOf(methodTestBase{}), reflect.TypeOf((*interface{ ID() int })(nil)))
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		assert.False(t, pointerOnly)
+	})
+
+	t.Run("#3: not implemented", func(t *testing.T) {
+		ok, pointerOnly, err := Implements(valOf(methodTestBase{}), ifaceType)
+		assert.Nil(t, err)
+		assert.False(t, ok)
+		assert.False(t, pointerOnly)
+	})
+
+	t.Run("#4: interface input", func(t *testing.T) {
+		ok, _, err := Implements(valOf((*io.ReadCloser)(nil)), reflect.TypeOf((*io.Reader)(nil)))
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	})
+}
+
+func Test_Implements_failure(t *testing.T) {
+	_, _, err := Implements(valOf(methodTestBase{}), reflect.TypeOf(0))
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+	_, _, err = Implements(reflect.Value{}, reflect.TypeOf((*io.Reader)(nil)))
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+}
+
+func Test_MissingMethods(t *testing.T) {
+	ifaceType := reflect.TypeOf((*methodTestHandlerIface)(nil)).Elem()
+
+	t.Run("#1: pointer receiver methods are missing in value type", func(t *testing.T) {
+		missing, err := MissingMethods(reflect.TypeOf(methodTestHandler{}), ifaceType)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Handle(string, ...interface {}) error"}, missing)
+
+		missing, err = MissingMethods(reflect.TypeOf(&methodTestHandler{}), ifaceType)
+		assert.Nil(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("#2: signature unmatched", func(t *testing.T) {
+		missing, err := MissingMethods(reflect.TypeOf(methodTestBase{}), reflect.TypeOf((*interface {
+			ID() string
+			Close() error
+		})(nil)))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Close() error", "ID() string"}, missing)
+	})
+
+	t.Run("#3: interface type", func(t *testing.T) {
+		missing, err := MissingMethods(reflect.TypeOf((*io.Reader)(nil)).Elem(), reflect.TypeOf((*io.ReadCloser)(nil)))
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Close() error"}, missing)
+	})
+}
+
+func Test_MissingMethods_failure(t *testing.T) {
+	_, err := MissingMethods(reflect.TypeOf(0), reflect.TypeOf(0))
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+}
@@ -0,0 +1,51 @@
+package rflutil
+
+// Option an option to customize behaviors of the functions accepting it
+type Option func(*options)
+
+type options struct {
+	autoInit     bool
+	unwrapError  bool
+	recoverPanic bool
+	typeRegistry *TypeRegistry
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithAutoInit allows allocating nil maps, slices, and pointers automatically when setting values.
+// The nil values must be settable (e.g. they are accessed via pointers), otherwise they are left as is.
+func WithAutoInit() Option {
+	return func(o *options) {
+		o.autoInit = true
+	}
+}
+
+// WithUnwrapError makes CallFuncWith and CallMethodWith remove the trailing `error` result
+// of the called function from the results and return it as the error.
+func WithUnwrapError() Option {
+	return func(o *options) {
+		o.unwrapError = true
+	}
+}
+
+// WithRecoverPanic makes CallFuncWith and CallMethodWith recover panics of the called function
+// and return them as *PanicError which matches ErrPanicRecovered and wraps the recovered error if any.
+func WithRecoverPanic() Option {
+	return func(o *options) {
+		o.recoverPanic = true
+	}
+}
+
+// WithTypeRegistry sets the registry used by MapToStruct to find the concrete types of the
+// interface values having the discriminator tag `poly`.
+func WithTypeRegistry(reg *TypeRegistry) Option {
+	return func(o *options) {
+		o.typeRegistry = reg
+	}
+}
@@ -0,0 +1,112 @@
+package rflutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithAutoInit(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type Sub struct {
+		M map[string]int
+		S []string
+	}
+	type SS struct {
+		*Base
+		Sub  *Sub
+		M    map[string]any
+		PM   *map[int]int
+		S    []int
+		PS   **[]int
+		Subs map[string]*Sub
+	}
+
+	t.Run("#1: MapSet", func(t *testing.T) {
+		s := SS{}
+		err := MapSet(valOf(&s.M), "k", 1)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		assert.Nil(t, s.M)
+
+		err = MapSet(valOf(&s.M), "k", 1, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"k": 1}, s.M)
+
+		err = MapSet(valOf(&s.PM), 1, 1, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, map[int]int{1: 1}, *s.PM)
+
+		// Not settable
+		err = MapSet(valOf(SS{}.M), "k", 1, WithAutoInit())
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#2: MapSetAs", func(t *testing.T) {
+		var m map[int64]float64
+		err := MapSetAs(valOf(&m), 1, 2)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		err = MapSetAs(valOf(&m), 1, 2, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, map[int64]float64{1: 2}, m)
+	})
+
+	t.Run("#3: SliceAppend", func(t *testing.T) {
+		s := SS{}
+		result, err := SliceAppend(valOf(&s.S), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1}, result)
+		assert.Nil(t, s.S)
+
+		result, err = SliceAppend(valOf(&s.S), 1, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1}, result)
+		assert.Equal(t, []int{1}, s.S)
+
+		_, err = SliceAppend(valOf(&s.PS), 2)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = SliceAppend(valOf(&s.PS), 2, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2}, **s.PS)
+	})
+
+	t.Run("#4: StructSetField", func(t *testing.T) {
+		s := SS{}
+		err := StructSetField(valOf(&s), "ID", 1, true)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		assert.Nil(t, s.Base)
+
+		err = StructSetField(valOf(&s), "ID", 1, true, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, 1, s.ID)
+
+		err = StructSetField(valOf(&s.Sub), "S", []string{"a"}, true)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		err = StructSetField(valOf(&s.Sub), "S", []string{"a"}, true, WithAutoInit())
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a"}, s.Sub.S)
+	})
+
+	t.Run("#5: Value wrapper", func(t *testing.T) {
+		s := SS{}
+		err := Of(&s).Field("Sub").Field("M").Key("k").Set(1)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+
+		err = Of(&s, WithAutoInit()).Field("Sub").Field("M").Key("k").Set(1)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"k": 1}, s.Sub.M)
+
+		err = Of(&s, WithAutoInit()).Field("ID").Set(2)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, s.ID)
+
+		err = Of(&s, WithAutoInit()).Field("Subs").Key("x").Set(&Sub{})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]*Sub{"x": {}}, s.Subs)
+
+		err = Of(&s, WithAutoInit()).Field("PM").Key(1).Set(1)
+		assert.Nil(t, err)
+		assert.Equal(t, map[int]int{1: 1}, *s.PM)
+	})
+}
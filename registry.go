@@ -0,0 +1,155 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// TypeRegistry a concurrency-safe registry mapping names to types, used to create values
+// of types by names such as the type discriminators in payloads.
+//
+// A named type is registered with its stable name formed by the package path and the type name,
+// e.g. "github.com/org/app/events.UserCreated". Custom aliases can be registered too.
+type TypeRegistry struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string // the primary name of each type
+}
+
+// NewTypeRegistry creates a new TypeRegistry
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		types: map[string]reflect.Type{},
+		names: map[reflect.Type]string{},
+	}
+}
+
+// TypeName returns the stable name of a type formed by the package path and the type name,
+// pointers are dereferenced. Returns empty string for unnamed and predeclared types.
+func TypeName(t reflect.Type) string {
+	typ := indirectTypeTilRoot(t)
+	if typ.Name() == "" || typ.PkgPath() == "" {
+		return ""
+	}
+	return typ.PkgPath() + "." + typ.Name()
+}
+
+// Register registers a type with its stable name (see TypeName) and the given aliases.
+// Pointers are dereferenced, so registering *T is the same as registering T.
+// Named types of the exported struct fields are registered too with their stable names, recursively.
+//
+// Unnamed types (e.g. []int) can only be registered with aliases. Registering a name already
+// used by another type results in ErrDuplicated, re-registering the same type is allowed.
+func (r *TypeRegistry) Register(t reflect.Type, aliases ...string) error {
+	typ := indirectTypeTilRoot(t)
+	names := aliases
+	if name := TypeName(typ); name != "" {
+		names = append([]string{name}, aliases...)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("%w: type %v is unnamed, an alias is required", ErrValueInvalid, t)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range names {
+		if name == "" {
+			return fmt.Errorf("%w: empty name", ErrValueInvalid)
+		}
+		if existing, ok := r.types[name]; ok && existing != typ {
+			return fmt.Errorf("%w: name '%s' is used by type %v", ErrDuplicated, name, existing)
+		}
+	}
+	for _, name := range names {
+		r.types[name] = typ
+	}
+	if _, ok := r.names[typ]; !ok {
+		r.names[typ] = names[0]
+	}
+	r.registerNested(typ, map[reflect.Type]struct{}{})
+	return nil
+}
+
+// registerNested registers the named types of the exported struct fields, the element types
+// of the containers, recursively. Types whose names are used by other types are skipped.
+func (r *TypeRegistry) registerNested(typ reflect.Type, visited map[reflect.Type]struct{}) {
+	if _, ok := visited[typ]; ok {
+		return
+	}
+	visited[typ] = struct{}{}
+
+	if name := TypeName(typ); name != "" && typ.Kind() != reflect.Pointer {
+		if _, ok := r.types[name]; !ok {
+			r.types[name] = typ
+		}
+		if _, ok := r.names[typ]; !ok && r.types[name] == typ {
+			r.names[typ] = name
+		}
+	}
+
+	switch typ.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Chan:
+		r.registerNested(typ.Elem(), visited)
+	case reflect.Map:
+		r.registerNested(typ.Key(), visited)
+		r.registerNested(typ.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if sf := typ.Field(i); sf.IsExported() {
+				r.registerNested(sf.Type, visited)
+			}
+		}
+	}
+}
+
+// Lookup finds the type registered with the given name
+func (r *TypeRegistry) Lookup(name string) (reflect.Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	typ, ok := r.types[name]
+	return typ, ok
+}
+
+// New creates a pointer to a new zero value of the type registered with the given name
+func (r *TypeRegistry) New(name string) (reflect.Value, error) {
+	typ, ok := r.Lookup(name)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("%w: type name '%s'", ErrNotFound, name)
+	}
+	return reflect.New(typ), nil
+}
+
+// NameOf returns the primary name of the type of a value, which is the stable name of the type,
+// or the first alias if the type is unnamed. Pointers and interfaces are dereferenced.
+func (r *TypeRegistry) NameOf(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", fmt.Errorf("%w: value is invalid", ErrTypeInvalid)
+	}
+	typ := indirectTypeTilRoot(v.Type())
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.names[typ]
+	if !ok {
+		return "", fmt.Errorf("%w: type %v is not registered", ErrNotFound, typ)
+	}
+	return name, nil
+}
+
+// Names returns all registered names including aliases in sorted order
+func (r *TypeRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,127 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type registryTestAddress struct {
+	City string
+}
+
+type registryTestTag string
+
+type registryTestUser struct {
+	Name    string
+	Home    *registryTestAddress
+	Tags    []registryTestTag
+	Created time.Time
+	Friends map[string]*registryTestUser
+}
+
+const registryTestPkg = "github.com/tiendc/go-rflutil."
+
+func Test_TypeName(t *testing.T) {
+	assert.Equal(t, registryTestPkg+"registryTestUser", TypeName(reflect.TypeOf(&registryTestUser{})))
+	assert.Equal(t, "time.Time", TypeName(reflect.TypeOf(time.Time{})))
+	assert.Equal(t, "", TypeName(reflect.TypeOf(0)))
+	assert.Equal(t, "", TypeName(reflect.TypeOf([]registryTestUser{})))
+}
+
+func Test_TypeRegistry(t *testing.T) {
+	t.Run("#1: register with stable name and aliases", func(t *testing.T) {
+		reg := NewTypeRegistry()
+		err := reg.Register(reflect.TypeOf(&registryTestUser{}), "user", "user.v1")
+		assert.Nil(t, err)
+
+		for _, name := range []string{registryTestPkg + "registryTestUser", "user", "user.v1"} {
+			typ, ok := reg.Lookup(name)
+			assert.True(t, ok)
+			assert.Equal(t, reflect.TypeOf(registryTestUser{}), typ)
+		}
+
+		v, err := reg.New("user")
+		assert.Nil(t, err)
+		assert.Equal(t, &registryTestUser{}, v.Interface())
+
+		name, err := reg.NameOf(valOf(&registryTestUser{}))
+		assert.Nil(t, err)
+		assert.Equal(t, registryTestPkg+"registryTestUser", name)
+
+		// Re-registering is allowed
+		assert.Nil(t, reg.Register(reflect.TypeOf(registryTestUser{}), "user"))
+	})
+
+	t.Run("#2: nested types are registered", func(t *testing.T) {
+		reg := NewTypeRegistry()
+		assert.Nil(t, reg.Register(reflect.TypeOf(registryTestUser{})))
+		assert.Equal(t, []string{
+			registryTestPkg + "registryTestAddress",
+			registryTestPkg + "registryTestTag",
+			registryTestPkg + "registryTestUser",
+			"time.Time",
+		}, reg.Names())
+
+		v, err := reg.New(registryTestPkg + "registryTestAddress")
+		assert.Nil(t, err)
+		assert.Equal(t, &registryTestAddress{}, v.Interface())
+	})
+
+	t.Run("#3: unnamed type with alias", func(t *testing.T) {
+		reg := NewTypeRegistry()
+		assert.Nil(t, reg.Register(reflect.TypeOf(map[string]int{}), "counters"))
+		name, err := reg.NameOf(valOf(map[string]int{}))
+		assert.Nil(t, err)
+		assert.Equal(t, "counters", name)
+	})
+
+	t.Run("#4: concurrent access", func(t *testing.T) {
+		reg := NewTypeRegistry()
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				alias := fmt.Sprintf("user%d", i)
+				assert.Nil(t, reg.Register(reflect.TypeOf(registryTestUser{}), alias))
+				_, err := reg.New(alias)
+				assert.Nil(t, err)
+				_, err = reg.NameOf(valOf(registryTestUser{}))
+				assert.Nil(t, err)
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, 14, len(reg.Names()))
+	})
+}
+
+func Test_TypeRegistry_failure(t *testing.T) {
+	t.Run("#1: unnamed type without alias", func(t *testing.T) {
+		err := NewTypeRegistry().Register(reflect.TypeOf([]int{}))
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		err = NewTypeRegistry().Register(reflect.TypeOf([]int{}), "")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#2: name used by another type", func(t *testing.T) {
+		reg := NewTypeRegistry()
+		assert.Nil(t, reg.Register(reflect.TypeOf(registryTestUser{}), "x"))
+		err := reg.Register(reflect.TypeOf(registryTestAddress{}), "x")
+		assert.ErrorIs(t, err, ErrDuplicated)
+	})
+
+	t.Run("#3: name not found", func(t *testing.T) {
+		reg := NewTypeRegistry()
+		_, err := reg.New("x")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = reg.NameOf(valOf(registryTestUser{}))
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = reg.NameOf(reflect.Value{})
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
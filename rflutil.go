@@ -7,15 +7,36 @@ import (
 
 func ValueAs[T any](v reflect.Value) (T, error) {
 	var ret T
-	targetType := reflect.TypeOf(ret)
+	val, err := valueConvert(v, reflect.TypeOf((*T)(nil)).Elem(), false)
+	if err != nil {
+		return ret, err
+	}
+	// NOTE: a nil interface value fails the type assertion, zero T is returned for it
+	ret, _ = val.Interface().(T)
+	return ret, nil
+}
+
+// valueConvert converts a value to the target type. The value is returned as is if it is assignable
+// to the target type, otherwise it is converted if convertible. Interfaces are unwrapped when needed.
+// An invalid value (untyped nil) is converted to the zero value of the nillable target types.
+// When noRunes is set, conversions of integers to strings and between strings and rune slices
+// are not performed (see isRuneConversion).
+func valueConvert(v reflect.Value, targetType reflect.Type, noRunes bool) (reflect.Value, error) {
+	if !v.IsValid() {
+		if isKindIn(targetType.Kind(), reflect.Interface, reflect.Pointer, reflect.Map,
+			reflect.Slice, reflect.Func, reflect.Chan) {
+			return reflect.Zero(targetType), nil
+		}
+		return reflect.Value{}, fmt.Errorf("%w: value is nil (expect %v)", ErrTypeUnmatched, targetType)
+	}
 	sourceType := v.Type()
 
 	for {
 		if sourceType.AssignableTo(targetType) {
-			return v.Interface().(T), nil // nolint: forcetypeassert
+			return v, nil
 		}
-		if sourceType.ConvertibleTo(targetType) {
-			return v.Convert(targetType).Interface().(T), nil // nolint: forcetypeassert
+		if sourceType.ConvertibleTo(targetType) && (!noRunes || !isRuneConversion(sourceType, targetType)) {
+			return v.Convert(targetType), nil
 		}
 
 		if v.IsValid() && v.Kind() == reflect.Interface {
@@ -29,5 +50,23 @@ func ValueAs[T any](v reflect.Value) (T, error) {
 		}
 	}
 
-	return ret, fmt.Errorf("%w: value type is %v (expect %v)", ErrTypeUnmatched, sourceType, targetType)
+	return reflect.Value{}, fmt.Errorf("%w: value type is %v (expect %v)", ErrTypeUnmatched, sourceType, targetType)
+}
+
+// isRuneConversion checks if a conversion treats integers as runes (e.g. string(rune(65)) is "A"),
+// or converts between strings and rune slices. Such conversions are allowed by Go, but they are
+// unexpected when converting values, e.g. 65 is not converted to "A".
+func isRuneConversion(sourceType, targetType reflect.Type) bool {
+	isRuneSlice := func(t reflect.Type) bool {
+		return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Int32
+	}
+	switch targetType.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return isRuneSlice(sourceType) || isKindIn(sourceType.Kind(), reflect.Int, reflect.Int8, reflect.Int16,
+			reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+			reflect.Uint64, reflect.Uintptr)
+	case reflect.Slice:
+		return isRuneSlice(targetType) && sourceType.Kind() == reflect.String
+	}
+	return false
 }
@@ -1,6 +1,7 @@
 package rflutil
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,6 +57,22 @@ func Test_ValueAs(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, int64(123), v)
 	})
+
+	t.Run("#9: to interface type", func(t *testing.T) {
+		v, err := ValueAs[any](valOf(123))
+		assert.Nil(t, err)
+		assert.Equal(t, 123, v)
+
+		v, err = ValueAs[any](reflect.Value{})
+		assert.Nil(t, err)
+		assert.Nil(t, v)
+	})
+
+	t.Run("#10: nil to nillable type", func(t *testing.T) {
+		v, err := ValueAs[[]int](reflect.Value{})
+		assert.Nil(t, err)
+		assert.Nil(t, v)
+	})
 }
 
 func Test_ValueAs_failure(t *testing.T) {
@@ -74,4 +91,9 @@ func Test_ValueAs_failure(t *testing.T) {
 		_, err := ValueAs[[]int64](valOf([]int{1, 2, 3}))
 		assert.ErrorIs(t, err, ErrTypeUnmatched)
 	})
+
+	t.Run("#4: nil to non-nillable type", func(t *testing.T) {
+		_, err := ValueAs[int](reflect.Value{})
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
 }
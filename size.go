@@ -0,0 +1,169 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"unsafe"
+)
+
+// approximations of the runtime structures, based on the 64-bit runtime
+const (
+	sizeMapHeader      = 48  // runtime.hmap
+	sizeMapBucketSlots = 8   // number of entries per map bucket
+	sizeMapMaxSlot     = 128 // keys and values bigger than this are stored indirectly in map buckets
+	sizeChanHeader     = 96  // runtime.hchan
+
+	// the load factor of maps is 13/2 entries per bucket
+	sizeMapLoadFactorNum = 13
+	sizeMapLoadFactorDen = 2
+)
+
+const (
+	sizePointer = unsafe.Sizeof(uintptr(0))
+)
+
+// FieldSize size of a struct field reported by DeepSizeByFields
+type FieldSize struct {
+	Name string
+	Size uintptr
+}
+
+// DeepSize estimates the number of bytes retained by a value, including the memory reachable from it.
+// It accounts for the capacity of slices, the buckets of maps (approximately), the bytes of strings,
+// the values boxed in interfaces, and the buffers of channels. Memory pointed to by multiple pointers
+// (or shared by slices and maps) is counted once.
+//
+// This is an estimation, the memory of closures, the elements in channel buffers, and the allocation
+// overhead are not counted. The location of time.Time is considered shared and not counted.
+func DeepSize(v reflect.Value) uintptr {
+	if !v.IsValid() {
+		return 0
+	}
+	s := &sizer{visited: map[visitedPtr]struct{}{}}
+	return v.Type().Size() + s.indirectSize(v)
+}
+
+// DeepSizeByFields estimates the retained bytes of each field of a struct the same way as DeepSize does,
+// the result is sorted by sizes descending to show which fields dominate. Input v can be a struct or
+// a pointer to a struct. Memory shared by multiple fields is counted for the first of them only.
+func DeepSizeByFields(v reflect.Value) ([]FieldSize, error) {
+	val := indirectValueTilRoot(v)
+	if !val.IsValid() || val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: require struct type", ErrTypeInvalid)
+	}
+
+	s := &sizer{visited: map[visitedPtr]struct{}{}}
+	typ := val.Type()
+	result := make([]FieldSize, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := val.Field(i)
+		result = append(result, FieldSize{
+			Name: typ.Field(i).Name,
+			Size: field.Type().Size() + s.indirectSize(field),
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Size > result[j].Size
+	})
+	return result, nil
+}
+
+type sizer struct {
+	visited map[visitedPtr]struct{}
+}
+
+// visit marks a memory block as counted, returns false if it was counted already
+func (s *sizer) visit(v reflect.Value) bool {
+	key := visitedPtr{ptr: v.Pointer(), typ: v.Type()}
+	if _, ok := s.visited[key]; ok {
+		return false
+	}
+	s.visited[key] = struct{}{}
+	return true
+}
+
+// indirectSize calculates the size of the memory reachable from a value, excluding the value itself
+//
+//nolint:gocyclo
+func (s *sizer) indirectSize(v reflect.Value) uintptr {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return uintptr(v.Len())
+	case reflect.Pointer:
+		if v.IsNil() || !s.visit(v) {
+			return 0
+		}
+		return v.Type().Elem().Size() + s.indirectSize(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		elem := v.Elem()
+		if isKindIn(elem.Kind(), reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer) {
+			// Pointer-shaped values are stored directly in interfaces
+			return s.indirectSize(elem)
+		}
+		return elem.Type().Size() + s.indirectSize(elem)
+	case reflect.Slice:
+		if v.IsNil() || !s.visit(v) {
+			return 0
+		}
+		size := uintptr(v.Cap()) * v.Type().Elem().Size()
+		for i := 0; i < v.Len(); i++ {
+			size += s.indirectSize(v.Index(i))
+		}
+		return size
+	case reflect.Array:
+		var size uintptr
+		for i := 0; i < v.Len(); i++ {
+			size += s.indirectSize(v.Index(i))
+		}
+		return size
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return 0
+		}
+		var size uintptr
+		for i := 0; i < v.NumField(); i++ {
+			size += s.indirectSize(v.Field(i))
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || !s.visit(v) {
+			return 0
+		}
+		size := mapBucketsSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			size += s.indirectSize(iter.Key()) + s.indirectSize(iter.Value())
+		}
+		return size
+	case reflect.Chan:
+		if v.IsNil() || !s.visit(v) {
+			return 0
+		}
+		return sizeChanHeader + uintptr(v.Cap())*v.Type().Elem().Size()
+	}
+	return 0
+}
+
+// mapBucketsSize estimates the size of a map having n entries, using the bucket layout
+// and the load factor (6.5 entries per bucket) of the runtime
+func mapBucketsSize(typ reflect.Type, n int) uintptr {
+	slotSize := func(t reflect.Type) (slot uintptr, extra uintptr) {
+		if t.Size() > sizeMapMaxSlot {
+			return sizePointer, t.Size()
+		}
+		return t.Size(), 0
+	}
+	keySlot, keyExtra := slotSize(typ.Key())
+	elemSlot, elemExtra := slotSize(typ.Elem())
+	bucketSize := sizeMapBucketSlots*(1+keySlot+elemSlot) + sizePointer
+
+	buckets := uintptr(1)
+	for uintptr(n)*sizeMapLoadFactorDen > buckets*sizeMapLoadFactorNum {
+		buckets <<= 1
+	}
+	return sizeMapHeader + buckets*bucketSize + uintptr(n)*(keyExtra+elemExtra)
+}
@@ -0,0 +1,105 @@
+package rflutil
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DeepSize(t *testing.T) {
+	t.Run("#1: basic values", func(t *testing.T) {
+		assert.Equal(t, uintptr(0), DeepSize(valOf(nil)))
+		assert.Equal(t, uintptr(8), DeepSize(valOf(int64(1))))
+		assert.Equal(t, unsafe.Sizeof("")+5, DeepSize(valOf("hello")))
+		assert.Equal(t, unsafe.Sizeof(time.Time{}), DeepSize(valOf(time.Now())))
+	})
+
+	t.Run("#2: slices count capacity", func(t *testing.T) {
+		s := make([]int32, 2, 10)
+		assert.Equal(t, unsafe.Sizeof(s)+40, DeepSize(valOf(s)))
+		assert.Equal(t, unsafe.Sizeof(s), DeepSize(valOf([]int32(nil))))
+
+		strs := []string{"ab", "cde"}
+		assert.Equal(t, unsafe.Sizeof(strs)+2*unsafe.Sizeof("")+5, DeepSize(valOf(strs)))
+	})
+
+	t.Run("#3: pointers visited once", func(t *testing.T) {
+		type Node struct {
+			Next *Node
+			Val  int64
+		}
+		n := &Node{Val: 1}
+		n.Next = n
+		assert.Equal(t, unsafe.Sizeof(n)+unsafe.Sizeof(Node{}), DeepSize(valOf(n)))
+
+		shared := &Node{}
+		pair := [2]*Node{shared, shared}
+		assert.Equal(t, unsafe.Sizeof(pair)+unsafe.Sizeof(Node{}), DeepSize(valOf(pair)))
+	})
+
+	t.Run("#4: interfaces", func(t *testing.T) {
+		items := []any{int64(1), "ab", nil}
+		assert.Equal(t, unsafe.Sizeof(items)+3*16+8+unsafe.Sizeof("")+2, DeepSize(valOf(items)))
+	})
+
+	t.Run("#5: maps grow with entries", func(t *testing.T) {
+		small := map[string]int{"a": 1}
+		big := map[string]int{}
+		for i := 0; i < 1000; i++ {
+			big[string(rune('a'+i%26))+string(rune(i))] = i
+		}
+		smallSize := DeepSize(valOf(small))
+		bigSize := DeepSize(valOf(big))
+		assert.Greater(t, smallSize, unsafe.Sizeof(small)+sizeMapHeader)
+		assert.Greater(t, bigSize, 1000*(unsafe.Sizeof("")+8))
+		assert.Equal(t, unsafe.Sizeof(small), DeepSize(valOf(map[string]int(nil))))
+
+		type Big [200]byte
+		bigVal := map[int]Big{1: {}}
+		assert.Greater(t, DeepSize(valOf(bigVal)), uintptr(200))
+	})
+
+	t.Run("#6: channels", func(t *testing.T) {
+		ch := make(chan int64, 10)
+		assert.Equal(t, unsafe.Sizeof(ch)+sizeChanHeader+80, DeepSize(valOf(ch)))
+	})
+}
+
+func Test_DeepSizeByFields(t *testing.T) {
+	type Cache struct {
+		Name    string
+		Items   []int64
+		Index   map[string]int
+		Shared  *[100]byte
+		Shared2 *[100]byte
+		secret  string
+	}
+	arr := &[100]byte{}
+	c := &Cache{Name: "c", Items: make([]int64, 0, 50), Shared: arr, Shared2: arr, secret: "abc"}
+
+	fields, err := DeepSizeByFields(valOf(c))
+	assert.Nil(t, err)
+	assert.Equal(t, []FieldSize{
+		{Name: "Items", Size: unsafe.Sizeof(c.Items) + 400},
+		{Name: "Shared", Size: unsafe.Sizeof(arr) + 100},
+		{Name: "secret", Size: unsafe.Sizeof("") + 3},
+		{Name: "Name", Size: unsafe.Sizeof("") + 1},
+		{Name: "Index", Size: unsafe.Sizeof(c.Index)},
+		{Name: "Shared2", Size: unsafe.Sizeof(arr)},
+	}, fields)
+
+	var total uintptr
+	for _, f := range fields {
+		total += f.Size
+	}
+	assert.Equal(t, DeepSize(valOf(*c)), total)
+}
+
+func Test_DeepSizeByFields_failure(t *testing.T) {
+	_, err := DeepSizeByFields(valOf(1))
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+	_, err = DeepSizeByFields(valOf((*struct{})(nil)))
+	assert.ErrorIs(t, err, ErrTypeInvalid)
+}
@@ -54,43 +54,33 @@ func SliceSet[T any](s reflect.Value, i int, v T) error {
 		return fmt.Errorf("%w: index %d is out of range", ErrIndexOutOfRange, i)
 	}
 
-	itemType := slice.Type().Elem()
-	val := reflect.ValueOf(v)
-	if !val.IsValid() {
-		if itemType.Kind() == reflect.Interface {
-			slice.Index(i).Set(reflect.Zero(itemType))
-			return nil
-		}
-		return fmt.Errorf("%w: item type is %v (expect %v)",
-			ErrTypeUnmatched, itemType, reflect.TypeOf([]interface{}{}).Elem())
-	}
-	if !val.Type().AssignableTo(itemType) {
-		return fmt.Errorf("%w: item type is %v (expect %v)", ErrTypeUnmatched, itemType, val.Type())
+	val, err := sliceItemValue(slice.Type().Elem(), v)
+	if err != nil {
+		return err
 	}
 	slice.Index(i).Set(val)
 	return nil
 }
 
-// SliceAppend appends the given value to a slice
-func SliceAppend[T any](s reflect.Value, v T) ([]T, error) {
-	slice := indirectValueTilRoot(s)
+// SliceAppend appends the given value to a slice.
+// With option WithAutoInit, the result is also set back to the slice if it is settable (e.g. the input
+// is a pointer to a slice), so a nil slice can grow in place. Nil pointers on the way are allocated too.
+func SliceAppend[T any](s reflect.Value, v T, opts ...Option) ([]T, error) {
+	autoInit := newOptions(opts).autoInit
+	slice := indirectValueTilRootEx(s, autoInit)
 	if !slice.IsValid() || slice.Kind() != reflect.Slice {
 		return nil, fmt.Errorf("%w: require slice type (got %v)", ErrTypeInvalid, s.Type())
 	}
 
-	itemType := slice.Type().Elem()
-	val := reflect.ValueOf(v)
-	if !val.IsValid() {
-		if itemType.Kind() == reflect.Interface {
-			return reflect.Append(slice, reflect.Zero(itemType)).Interface().([]T), nil // nolint: forcetypeassert
-		}
-		return nil, fmt.Errorf("%w: item type is %v (expect %v)",
-			ErrTypeUnmatched, itemType, reflect.TypeOf([]interface{}{}).Elem())
+	val, err := sliceItemValue(slice.Type().Elem(), v)
+	if err != nil {
+		return nil, err
 	}
-	if !val.Type().AssignableTo(itemType) {
-		return nil, fmt.Errorf("%w: item type is %v (expect %v)", ErrTypeUnmatched, itemType, val.Type())
+	result := reflect.Append(slice, val)
+	if autoInit && slice.CanSet() {
+		slice.Set(result)
 	}
-	return reflect.Append(slice, val).Interface().([]T), nil // nolint: forcetypeassert
+	return result.Interface().([]T), nil // nolint: forcetypeassert
 }
 
 // SliceGetAll get all elements of a slice
@@ -126,3 +116,132 @@ func SliceAs[T any](s reflect.Value) ([]T, error) {
 	}
 	return ret, nil
 }
+
+// SliceInsert inserts the given values into a slice at the given index.
+// If the input is a pointer to a slice, the slice is updated in place.
+// The result slice is returned in both cases.
+func SliceInsert[T any](s reflect.Value, i int, values ...T) (reflect.Value, error) {
+	return SliceSplice(s, i, 0, values...)
+}
+
+// SliceRemoveAt removes the element at the given index from a slice.
+// If the input is a pointer to a slice, the slice is updated in place.
+// The result slice is returned in both cases.
+func SliceRemoveAt(s reflect.Value, i int) (reflect.Value, error) {
+	slice, err := sliceForUpdate(s)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if i < 0 || i >= slice.Len() {
+		return reflect.Value{}, fmt.Errorf("%w: index %d is out of range", ErrIndexOutOfRange, i)
+	}
+	return sliceSplice(slice, i, 1, nil), nil
+}
+
+// SliceSplice removes `deleteCount` elements from the `start` index of a slice, then inserts
+// the given values at the index. If the input is a pointer to a slice, the slice is updated
+// in place. The result slice is returned in both cases.
+func SliceSplice[T any](s reflect.Value, start, deleteCount int, values ...T) (reflect.Value, error) {
+	slice, err := sliceForUpdate(s)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if start < 0 || start > slice.Len() {
+		return reflect.Value{}, fmt.Errorf("%w: index %d is out of range", ErrIndexOutOfRange, start)
+	}
+	if deleteCount < 0 || start+deleteCount > slice.Len() {
+		return reflect.Value{}, fmt.Errorf("%w: delete count %d is out of range", ErrIndexOutOfRange, deleteCount)
+	}
+
+	itemType := slice.Type().Elem()
+	items := make([]reflect.Value, 0, len(values))
+	for _, v := range values {
+		item, err := sliceItemValue(itemType, v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		items = append(items, item)
+	}
+	return sliceSplice(slice, start, deleteCount, items), nil
+}
+
+// SliceResize changes length of a slice. New elements are zero values.
+// If the input is a pointer to a slice, the slice is updated in place.
+// The result slice is returned in both cases.
+func SliceResize(s reflect.Value, n int) (reflect.Value, error) {
+	slice, err := sliceForUpdate(s)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if n < 0 {
+		return reflect.Value{}, fmt.Errorf("%w: size must not be negative (got %d)", ErrValueInvalid, n)
+	}
+
+	result := reflect.MakeSlice(slice.Type(), n, n)
+	reflect.Copy(result, slice)
+	if slice.CanSet() {
+		slice.Set(result)
+	}
+	return result, nil
+}
+
+// SliceClear removes all elements of a slice.
+// If the input is a pointer to a slice, the slice is updated in place with keeping its capacity.
+// The result slice is returned in both cases, it is a new value not referring to the input variable.
+func SliceClear(s reflect.Value) (reflect.Value, error) {
+	slice, err := sliceForUpdate(s)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if !slice.CanSet() {
+		return reflect.MakeSlice(slice.Type(), 0, 0), nil
+	}
+
+	// Zeroes the elements to not keep references to them
+	zero := reflect.Zero(slice.Type().Elem())
+	for i := 0; i < slice.Len(); i++ {
+		slice.Index(i).Set(zero)
+	}
+	result := slice.Slice(0, 0)
+	slice.Set(result)
+	return result, nil
+}
+
+// sliceForUpdate gets the slice from the input to update
+func sliceForUpdate(s reflect.Value) (reflect.Value, error) {
+	slice := indirectValueTilRoot(s)
+	if !slice.IsValid() || slice.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("%w: require slice type (got %v)", ErrTypeInvalid, s.Type())
+	}
+	return slice, nil
+}
+
+// sliceSplice builds a new slice from the given slice with removing and inserting items.
+// The input slice is updated in place if it is settable.
+func sliceSplice(slice reflect.Value, start, deleteCount int, items []reflect.Value) reflect.Value {
+	length := slice.Len()
+	result := reflect.MakeSlice(slice.Type(), 0, length-deleteCount+len(items))
+	result = reflect.AppendSlice(result, slice.Slice(0, start))
+	result = reflect.Append(result, items...)
+	result = reflect.AppendSlice(result, slice.Slice(start+deleteCount, length))
+	if slice.CanSet() {
+		slice.Set(result)
+	}
+	return result
+}
+
+// sliceItemValue gets reflect.Value of the given value to set as an item of a slice
+func sliceItemValue[T any](itemType reflect.Type, v T) (reflect.Value, error) {
+	val := reflect.ValueOf(v)
+	if !val.IsValid() {
+		if itemType.Kind() == reflect.Interface {
+			return reflect.Zero(itemType), nil
+		}
+		return reflect.Value{}, fmt.Errorf("%w: item type is %v (expect %v)",
+			ErrTypeUnmatched, itemType, reflect.TypeOf([]interface{}{}).Elem())
+	}
+	if !val.Type().AssignableTo(itemType) {
+		return reflect.Value{}, fmt.Errorf("%w: item type is %v (expect %v)", ErrTypeUnmatched, itemType, val.Type())
+	}
+	return val, nil
+}
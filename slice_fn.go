@@ -0,0 +1,83 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SliceFilter returns a new slice of the elements satisfying the given function.
+// Input can be a slice or an array, the result is a slice of the same element type.
+func SliceFilter(s reflect.Value, fn func(i int, v reflect.Value) bool) (reflect.Value, error) {
+	slice, err := sliceOrArrayOf(s)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	length := slice.Len()
+	result := reflect.MakeSlice(reflect.SliceOf(slice.Type().Elem()), 0, length)
+	for i := 0; i < length; i++ {
+		item := slice.Index(i)
+		if fn(i, item) {
+			result = reflect.Append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// SliceMap returns a new slice of the values returned by the given function for every element
+func SliceMap[T any](s reflect.Value, fn func(i int, v reflect.Value) T) ([]T, error) {
+	slice, err := sliceOrArrayOf(s)
+	if err != nil {
+		return nil, err
+	}
+
+	length := slice.Len()
+	result := make([]T, 0, length)
+	for i := 0; i < length; i++ {
+		result = append(result, fn(i, slice.Index(i)))
+	}
+	return result, nil
+}
+
+// SliceReduce reduces all elements to a single value by calling the given function for
+// every element with the accumulated value starting with `initial`
+func SliceReduce[T any](s reflect.Value, fn func(acc T, i int, v reflect.Value) T, initial T) (T, error) {
+	slice, err := sliceOrArrayOf(s)
+	if err != nil {
+		return initial, err
+	}
+
+	acc := initial
+	length := slice.Len()
+	for i := 0; i < length; i++ {
+		acc = fn(acc, i, slice.Index(i))
+	}
+	return acc, nil
+}
+
+// SliceFind finds the first element satisfying the given function, returns the element and its index.
+// ErrNotFound is returned when no element is found.
+func SliceFind(s reflect.Value, fn func(i int, v reflect.Value) bool) (reflect.Value, int, error) {
+	slice, err := sliceOrArrayOf(s)
+	if err != nil {
+		return reflect.Value{}, -1, err
+	}
+
+	length := slice.Len()
+	for i := 0; i < length; i++ {
+		item := slice.Index(i)
+		if fn(i, item) {
+			return item, i, nil
+		}
+	}
+	return reflect.Value{}, -1, fmt.Errorf("%w: no element satisfies the condition", ErrNotFound)
+}
+
+// sliceOrArrayOf gets the slice or array from the input behind pointers and interfaces
+func sliceOrArrayOf(s reflect.Value) (reflect.Value, error) {
+	slice := indirectValueTilRoot(s)
+	if !slice.IsValid() || !isKindIn(slice.Kind(), reflect.Slice, reflect.Array) {
+		return reflect.Value{}, fmt.Errorf("%w: require slice or array type (got %v)", ErrTypeInvalid, s.Type())
+	}
+	return slice, nil
+}
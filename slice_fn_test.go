@@ -0,0 +1,115 @@
+package rflutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SliceFilter(t *testing.T) {
+	isEven := func(i int, v reflect.Value) bool { return v.Int()%2 == 0 }
+
+	t.Run("#1: slice", func(t *testing.T) {
+		s := []int{1, 2, 3, 4}
+		v, err := SliceFilter(valOf(s), isEven)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2, 4}, v.Interface())
+	})
+
+	t.Run("#2: pointer to array", func(t *testing.T) {
+		a := [4]int{1, 2, 3, 4}
+		v, err := SliceFilter(valOf(&a), isEven)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2, 4}, v.Interface())
+	})
+
+	t.Run("#3: slice of interfaces", func(t *testing.T) {
+		var s any = []any{1, "a", nil, 2.5}
+		v, err := SliceFilter(valOf(&s), func(i int, v reflect.Value) bool {
+			return !v.IsNil() && v.Elem().Kind() != reflect.String
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []any{1, 2.5}, v.Interface())
+	})
+
+	t.Run("#4: nil slice", func(t *testing.T) {
+		var s []int
+		v, err := SliceFilter(valOf(s), isEven)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{}, v.Interface())
+	})
+
+	t.Run("#5: input is not slice", func(t *testing.T) {
+		_, err := SliceFilter(valOf("abc"), isEven)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_SliceMap(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		type Item struct {
+			Name string
+		}
+		s := []*Item{{Name: "a"}, {Name: "b"}}
+		names, err := SliceMap(valOf(s), func(i int, v reflect.Value) string {
+			return v.Elem().FieldByName("Name").String()
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b"}, names)
+	})
+
+	t.Run("#2: input is not slice", func(t *testing.T) {
+		_, err := SliceMap(valOf(123), func(i int, v reflect.Value) int { return i })
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_SliceReduce(t *testing.T) {
+	sum := func(acc float64, i int, v reflect.Value) float64 {
+		f, _ := ValueAs[float64](v)
+		return acc + f
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		v, err := SliceReduce(valOf([]any{1, 2.5, uint8(3)}), sum, 0.5)
+		assert.Nil(t, err)
+		assert.Equal(t, 7.0, v)
+	})
+
+	t.Run("#2: empty slice", func(t *testing.T) {
+		v, err := SliceReduce(valOf([0]int{}), sum, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1.0, v)
+	})
+
+	t.Run("#3: input is not slice", func(t *testing.T) {
+		_, err := SliceReduce(valOf(map[int]int{}), sum, 0)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_SliceFind(t *testing.T) {
+	gt := func(n int64) func(i int, v reflect.Value) bool {
+		return func(i int, v reflect.Value) bool { return v.Int() > n }
+	}
+
+	t.Run("#1: found", func(t *testing.T) {
+		v, i, err := SliceFind(valOf([]int{1, 5, 3, 7}), gt(4))
+		assert.Nil(t, err)
+		assert.Equal(t, 1, i)
+		assert.Equal(t, 5, v.Interface())
+	})
+
+	t.Run("#2: not found", func(t *testing.T) {
+		v, i, err := SliceFind(valOf([]int{1, 5, 3, 7}), gt(10))
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Equal(t, -1, i)
+		assert.False(t, v.IsValid())
+	})
+
+	t.Run("#3: input is not slice", func(t *testing.T) {
+		_, _, err := SliceFind(valOf("abc"), gt(1))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
@@ -0,0 +1,167 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SliceSortBy sorts a slice of structs by the given field paths. The sort is stable.
+// A path can be prefixed with "-" for descending order, e.g. SliceSortBy(s, "Profile.LastName", "-CreatedAt").
+// Supported field types are ordered kinds (bool, ints, uints, floats, string) and time.Time.
+// Nil values (nil pointers on the path) are considered smallest. An empty path means the element itself.
+// Input can be a slice or a pointer to an array.
+func SliceSortBy(s reflect.Value, paths ...string) error {
+	slice, err := sliceOrArrayOf(s)
+	if err != nil {
+		return err
+	}
+	if slice.Kind() == reflect.Array && !slice.CanSet() {
+		return fmt.Errorf("%w: sorting an array requires it to be addressable", ErrValueUnaddressable)
+	}
+
+	sortKeys := make([]sliceSortKey, 0, len(paths))
+	for _, path := range paths {
+		if strings.HasPrefix(path, "-") {
+			sortKeys = append(sortKeys, sliceSortKey{path: path[1:], desc: true})
+		} else {
+			sortKeys = append(sortKeys, sliceSortKey{path: path})
+		}
+	}
+	values, err := sliceSortValues(slice, sortKeys)
+	if err != nil {
+		return err
+	}
+
+	length := slice.Len()
+	order := make([]int, length)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		vi, vj := values[order[i]], values[order[j]]
+		for k := range sortKeys {
+			c := vi[k].compare(&vj[k])
+			if c == 0 {
+				continue
+			}
+			if sortKeys[k].desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := reflect.MakeSlice(reflect.SliceOf(slice.Type().Elem()), length, length)
+	for i, idx := range order {
+		sorted.Index(i).Set(slice.Index(idx))
+	}
+	reflect.Copy(slice, sorted)
+	return nil
+}
+
+type sliceSortKey struct {
+	path string
+	desc bool
+}
+
+// sliceSortValues extracts the values of the sort keys for all elements
+func sliceSortValues(slice reflect.Value, sortKeys []sliceSortKey) ([][]orderedValue, error) {
+	length := slice.Len()
+	values := make([][]orderedValue, length)
+	for i := 0; i < length; i++ {
+		values[i] = make([]orderedValue, len(sortKeys))
+		for k := range sortKeys {
+			fieldVal, err := structFieldByPath(slice.Index(i), sortKeys[k].path)
+			if err != nil {
+				return nil, err
+			}
+			ov, err := toOrderedValue(fieldVal)
+			if err != nil {
+				return nil, fmt.Errorf("%w (path '%s')", err, sortKeys[k].path)
+			}
+			values[i][k] = ov
+		}
+	}
+
+	// Values of a key must be of the same class
+	for k := range sortKeys {
+		class := orderedNil
+		for i := 0; i < length; i++ {
+			c := values[i][k].class
+			if c == orderedNil {
+				continue
+			}
+			if class != orderedNil && class != c {
+				return nil, fmt.Errorf("%w: values of path '%s' are of different types",
+					ErrTypeUnmatched, sortKeys[k].path)
+			}
+			class = c
+		}
+	}
+	return values, nil
+}
+
+// SliceGroupBy groups elements of a slice or an array by value of the given field path.
+// The field values must be comparable. Nil values (nil pointers on the path) are grouped with key `nil`.
+func SliceGroupBy(s reflect.Value, path string) (map[any][]reflect.Value, error) {
+	slice, err := sliceOrArrayOf(s)
+	if err != nil {
+		return nil, err
+	}
+
+	length := slice.Len()
+	result := make(map[any][]reflect.Value)
+	for i := 0; i < length; i++ {
+		item := slice.Index(i)
+		key, err := sliceItemKey(item, path)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = append(result[key], item)
+	}
+	return result, nil
+}
+
+// SliceIndexBy indexes elements of a slice or an array by value of the given field path.
+// The field values must be comparable and unique, otherwise ErrDuplicated is returned.
+func SliceIndexBy(s reflect.Value, path string) (map[any]reflect.Value, error) {
+	slice, err := sliceOrArrayOf(s)
+	if err != nil {
+		return nil, err
+	}
+
+	length := slice.Len()
+	result := make(map[any]reflect.Value, length)
+	for i := 0; i < length; i++ {
+		item := slice.Index(i)
+		key, err := sliceItemKey(item, path)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := result[key]; exists {
+			return nil, fmt.Errorf("%w: key '%v' of path '%s' at index %d", ErrDuplicated, key, path, i)
+		}
+		result[key] = item
+	}
+	return result, nil
+}
+
+// sliceItemKey gets value of the field path of an element to use as a map key
+func sliceItemKey(item reflect.Value, path string) (any, error) {
+	fieldVal, err := structFieldByPath(item, path)
+	if err != nil {
+		return nil, err
+	}
+	fieldVal = indirectValueTilRoot(fieldVal)
+	if !fieldVal.IsValid() {
+		return nil, nil
+	}
+	if !fieldVal.Type().Comparable() {
+		return nil, fmt.Errorf("%w: require comparable type for path '%s' (got %v)",
+			ErrTypeInvalid, path, fieldVal.Type())
+	}
+	return fieldVal.Interface(), nil
+}
@@ -0,0 +1,158 @@
+package rflutil
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sortTestProfile struct {
+	FirstName string
+	LastName  string
+}
+
+type sortTestUser struct {
+	ID        int
+	Profile   *sortTestProfile
+	Active    bool
+	Score     float64
+	CreatedAt time.Time
+	Tags      []string
+}
+
+func sortTestUsers() []*sortTestUser {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []*sortTestUser{
+		{ID: 1, Profile: &sortTestProfile{LastName: "b"}, CreatedAt: t0.Add(time.Hour), Score: 1.5},
+		{ID: 2, Profile: &sortTestProfile{LastName: "a"}, CreatedAt: t0, Active: true, Score: 2},
+		{ID: 3, Profile: nil, CreatedAt: t0.Add(2 * time.Hour), Score: 1.5},
+		{ID: 4, Profile: &sortTestProfile{LastName: "b"}, CreatedAt: t0.Add(3 * time.Hour), Active: true},
+		{ID: 5, Profile: &sortTestProfile{LastName: "a"}, CreatedAt: t0.Add(3 * time.Hour)},
+	}
+}
+
+func sortTestIDs(users []*sortTestUser) []int {
+	ids := make([]int, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func valuesToAny(values []reflect.Value) []any {
+	result := make([]any, 0, len(values))
+	for _, v := range values {
+		result = append(result, v.Interface())
+	}
+	return result
+}
+
+func Test_SliceSortBy(t *testing.T) {
+	t.Run("#1: single key", func(t *testing.T) {
+		users := sortTestUsers()
+		err := SliceSortBy(valOf(users), "CreatedAt")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{2, 1, 3, 4, 5}, sortTestIDs(users))
+	})
+
+	t.Run("#2: multiple keys with descending order", func(t *testing.T) {
+		users := sortTestUsers()
+		err := SliceSortBy(valOf(&users), "Profile.LastName", "-CreatedAt")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{3, 5, 2, 4, 1}, sortTestIDs(users))
+
+		err = SliceSortBy(valOf(users), "-Active", "Score", "ID")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{4, 2, 5, 1, 3}, sortTestIDs(users))
+	})
+
+	t.Run("#3: stable", func(t *testing.T) {
+		users := sortTestUsers()
+		err := SliceSortBy(valOf(users), "Score")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{4, 5, 1, 3, 2}, sortTestIDs(users))
+	})
+
+	t.Run("#4: elements of basic type", func(t *testing.T) {
+		s := []any{3, nil, 1, 2}
+		err := SliceSortBy(valOf(s), "-")
+		assert.Nil(t, err)
+		assert.Equal(t, []any{3, 2, 1, nil}, s)
+
+		a := [3]string{"b", "c", "a"}
+		err = SliceSortBy(valOf(&a), "")
+		assert.Nil(t, err)
+		assert.Equal(t, [3]string{"a", "b", "c"}, a)
+	})
+}
+
+func Test_SliceSortBy_failure(t *testing.T) {
+	t.Run("#1: input is not slice", func(t *testing.T) {
+		err := SliceSortBy(valOf("abc"), "A")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: array not addressable", func(t *testing.T) {
+		err := SliceSortBy(valOf([2]int{2, 1}))
+		assert.ErrorIs(t, err, ErrValueUnaddressable)
+	})
+
+	t.Run("#3: field not found", func(t *testing.T) {
+		err := SliceSortBy(valOf(sortTestUsers()), "Profile.Name")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#4: field not ordered", func(t *testing.T) {
+		err := SliceSortBy(valOf(sortTestUsers()), "Tags")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		err = SliceSortBy(valOf(sortTestUsers()), "ID.X")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#5: different types", func(t *testing.T) {
+		err := SliceSortBy(valOf([]any{1, "a"}), "")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
+}
+
+func Test_SliceGroupBy(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		users := sortTestUsers()
+		groups, err := SliceGroupBy(valOf(users), "Profile.LastName")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(groups))
+		assert.Equal(t, []any{users[1], users[4]}, valuesToAny(groups["a"]))
+		assert.Equal(t, []any{users[0], users[3]}, valuesToAny(groups["b"]))
+		assert.Equal(t, []any{users[2]}, valuesToAny(groups[nil]))
+	})
+
+	t.Run("#2: failure", func(t *testing.T) {
+		_, err := SliceGroupBy(valOf(123), "A")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = SliceGroupBy(valOf(sortTestUsers()), "Tags")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = SliceGroupBy(valOf(sortTestUsers()), "X")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func Test_SliceIndexBy(t *testing.T) {
+	t.Run("#1: success", func(t *testing.T) {
+		users := sortTestUsers()
+		index, err := SliceIndexBy(valOf(users), "ID")
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(index))
+		assert.Equal(t, users[2], index[3].Interface())
+	})
+
+	t.Run("#2: failure", func(t *testing.T) {
+		_, err := SliceIndexBy(valOf(123), "A")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = SliceIndexBy(valOf(sortTestUsers()), "Profile.LastName")
+		assert.ErrorIs(t, err, ErrDuplicated)
+		_, err = SliceIndexBy(valOf(sortTestUsers()), "X")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -252,3 +252,171 @@ func Test_SliceAs_failure(t *testing.T) {
 		assert.ErrorIs(t, err, ErrTypeUnmatched)
 	})
 }
+
+func Test_SliceInsert(t *testing.T) {
+	t.Run("#1: pointer input, updated in place", func(t *testing.T) {
+		s := []int{1, 2, 3}
+		v, err := SliceInsert(valOf(&s), 1, 11, 12)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 11, 12, 2, 3}, s)
+		assert.Equal(t, s, v.Interface())
+
+		_, err = SliceInsert(valOf(&s), 5, 13)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 11, 12, 2, 3, 13}, s)
+	})
+
+	t.Run("#2: value input, a new slice returned", func(t *testing.T) {
+		s := make([]int, 3, 10)
+		v, err := SliceInsert(valOf(s), 0, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{0, 0, 0}, s)
+		assert.Equal(t, []int{1, 0, 0, 0}, v.Interface())
+		assert.Equal(t, []int{0, 0, 0, 0}, s[:4])
+	})
+
+	t.Run("#3: interface item", func(t *testing.T) {
+		s := []any{1}
+		_, err := SliceInsert[any](valOf(&s), 0, nil, "a")
+		assert.Nil(t, err)
+		assert.Equal(t, []any{nil, "a", 1}, s)
+	})
+}
+
+func Test_SliceInsert_failure(t *testing.T) {
+	t.Run("#1: input is not slice", func(t *testing.T) {
+		_, err := SliceInsert(valOf(&[3]int{}), 0, 1)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: index out of range", func(t *testing.T) {
+		_, err := SliceInsert(valOf([]int{1}), 2, 1)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		_, err = SliceInsert(valOf([]int{1}), -1, 1)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	})
+
+	t.Run("#3: item type unmatched", func(t *testing.T) {
+		s := []int{1}
+		_, err := SliceInsert(valOf(&s), 0, "a")
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		_, err = SliceInsert[any](valOf(&s), 0, nil)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+		assert.Equal(t, []int{1}, s)
+	})
+}
+
+func Test_SliceRemoveAt(t *testing.T) {
+	t.Run("#1: pointer input, updated in place", func(t *testing.T) {
+		s := []int{1, 2, 3}
+		v, err := SliceRemoveAt(valOf(&s), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 3}, s)
+		assert.Equal(t, s, v.Interface())
+	})
+
+	t.Run("#2: value input, a new slice returned", func(t *testing.T) {
+		s := []int{1, 2, 3}
+		v, err := SliceRemoveAt(valOf(s), 0)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, s)
+		assert.Equal(t, []int{2, 3}, v.Interface())
+	})
+
+	t.Run("#3: failure", func(t *testing.T) {
+		_, err := SliceRemoveAt(valOf("abc"), 0)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = SliceRemoveAt(valOf([]int{1}), 1)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	})
+}
+
+func Test_SliceSplice(t *testing.T) {
+	t.Run("#1: pointer input, updated in place", func(t *testing.T) {
+		s := []string{"a", "b", "c", "d"}
+		v, err := SliceSplice(valOf(&s), 1, 2, "x", "y", "z")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "x", "y", "z", "d"}, s)
+		assert.Equal(t, s, v.Interface())
+	})
+
+	t.Run("#2: value input, a new slice returned", func(t *testing.T) {
+		s := []string{"a", "b", "c", "d"}
+		v, err := SliceSplice[string](valOf(s), 2, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, s)
+		assert.Equal(t, []string{"a", "b"}, v.Interface())
+	})
+
+	t.Run("#3: failure", func(t *testing.T) {
+		s := []string{"a", "b"}
+		_, err := SliceSplice(valOf(s), 3, 0, "x")
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		_, err = SliceSplice(valOf(s), 1, 2, "x")
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		_, err = SliceSplice(valOf(s), 1, -1, "x")
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		_, err = SliceSplice(valOf(s), 1, 1, 1)
+		assert.ErrorIs(t, err, ErrTypeUnmatched)
+	})
+}
+
+func Test_SliceResize(t *testing.T) {
+	t.Run("#1: pointer input, updated in place", func(t *testing.T) {
+		s := []int{1, 2, 3}
+		v, err := SliceResize(valOf(&s), 5)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3, 0, 0}, s)
+		assert.Equal(t, s, v.Interface())
+
+		_, err = SliceResize(valOf(&s), 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2}, s)
+	})
+
+	t.Run("#2: value input, a new slice returned", func(t *testing.T) {
+		var s []int
+		v, err := SliceResize(valOf(s), 2)
+		assert.Nil(t, err)
+		assert.Nil(t, s)
+		assert.Equal(t, []int{0, 0}, v.Interface())
+	})
+
+	t.Run("#3: failure", func(t *testing.T) {
+		_, err := SliceResize(valOf(123), 1)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = SliceResize(valOf([]int{}), -1)
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+}
+
+func Test_SliceClear(t *testing.T) {
+	t.Run("#1: pointer input, updated in place", func(t *testing.T) {
+		s := []*int{ptrOf(1), ptrOf(2)}
+		orig := s
+		v, err := SliceClear(valOf(&s))
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(s))
+		assert.Equal(t, 2, cap(s))
+		assert.Equal(t, s, v.Interface())
+		assert.Equal(t, []*int{nil, nil}, orig)
+
+		// The result doesn't change with the input variable
+		assert.False(t, v.CanSet())
+		s = append(s, ptrOf(3))
+		assert.Equal(t, 0, v.Len())
+	})
+
+	t.Run("#2: value input, a new slice returned", func(t *testing.T) {
+		s := []int{1, 2}
+		v, err := SliceClear(valOf(s))
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2}, s)
+		assert.Equal(t, []int{}, v.Interface())
+	})
+
+	t.Run("#3: failure", func(t *testing.T) {
+		_, err := SliceClear(valOf(map[int]int{}))
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
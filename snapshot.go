@@ -0,0 +1,97 @@
+package rflutil
+
+import (
+	"reflect"
+)
+
+// StructSnapshot holds copies of the field values of a struct to detect changes later
+type StructSnapshot struct {
+	typ    reflect.Type
+	fields []snapshotField
+}
+
+type snapshotField struct {
+	path  string
+	index []int
+	value reflect.Value // deep copy of the field value, invalid if the field is unreachable
+}
+
+// Snapshot takes a snapshot of a struct. Input should be a struct, a ptr to a struct,
+// or an interface containing a struct. Returns nil if the input is not a struct.
+//
+// Exported fields of the struct, of the embedded structs, and of the nested structs
+// are tracked. Nested structs having no exported fields (such as time.Time) are compared as a whole.
+// Fields of func and chan types are not tracked as their values can't be compared deeply.
+func Snapshot(v reflect.Value) *StructSnapshot {
+	val := indirectValueTilRoot(v)
+	if !val.IsValid() || val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := snapshotFieldsOf(val.Type(), "", nil)
+	for i := range fields {
+		field := &fields[i]
+		fieldVal, err := val.FieldByIndexErr(field.index)
+		if err != nil {
+			continue
+		}
+		field.value = deepCopyValue(fieldVal)
+	}
+	return &StructSnapshot{typ: val.Type(), fields: fields}
+}
+
+// Changed returns paths of the fields modified since the snapshot, e.g. ["Name", "Address.City"].
+// Returns nil if the input is not a struct of the same type as the snapshot one.
+func (snap *StructSnapshot) Changed(v reflect.Value) []string {
+	if snap == nil {
+		return nil
+	}
+	val := indirectValueTilRoot(v)
+	if !val.IsValid() || val.Type() != snap.typ {
+		return nil
+	}
+
+	var result []string
+	for i := range snap.fields {
+		field := &snap.fields[i]
+		fieldVal, err := val.FieldByIndexErr(field.index)
+		if err != nil {
+			if field.value.IsValid() {
+				result = append(result, field.path)
+			}
+			continue
+		}
+		if !field.value.IsValid() || !reflect.DeepEqual(field.value.Interface(), fieldVal.Interface()) {
+			result = append(result, field.path)
+		}
+	}
+	return result
+}
+
+// snapshotFieldsOf lists the fields to track of a struct type
+func snapshotFieldsOf(typ reflect.Type, pathPrefix string, indexPrefix []int) []snapshotField {
+	names, err := structListFields(typ, true)
+	if err != nil {
+		return nil
+	}
+
+	result := make([]snapshotField, 0, len(names))
+	for _, name := range names {
+		sf, _ := typ.FieldByName(name)
+		if isKindIn(sf.Type.Kind(), reflect.Func, reflect.Chan) {
+			continue
+		}
+		path := pathPrefix + name
+		index := append(append(make([]int, 0, len(indexPrefix)+len(sf.Index)), indexPrefix...), sf.Index...)
+
+		if sf.Type.Kind() == reflect.Struct {
+			nestedFields := snapshotFieldsOf(sf.Type, path+".", index)
+			if len(nestedFields) > 0 {
+				result = append(result, nestedFields...)
+				continue
+			}
+		}
+		result = append(result, snapshotField{path: path, index: index})
+	}
+	return result
+}
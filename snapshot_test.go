@@ -0,0 +1,102 @@
+package rflutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Snapshot(t *testing.T) {
+	type Address struct {
+		City   string
+		Street string
+	}
+	type Base struct {
+		ID        int
+		UpdatedAt time.Time
+	}
+	type SS struct {
+		Base
+		*Address
+		Name    string
+		Tags    []string
+		Attrs   map[string]any
+		Home    Address
+		Work    *Address
+		private int
+	}
+
+	newSS := func() *SS {
+		return &SS{
+			Base:  Base{ID: 1},
+			Name:  "name",
+			Tags:  []string{"a", "b"},
+			Attrs: map[string]any{"k": []int{1}},
+			Home:  Address{City: "city"},
+			Work:  &Address{City: "city2"},
+		}
+	}
+
+	t.Run("#1: not a struct", func(t *testing.T) {
+		assert.Nil(t, Snapshot(valOf(123)))
+		var snap *StructSnapshot
+		assert.Nil(t, snap.Changed(valOf(newSS())))
+	})
+
+	t.Run("#2: no change", func(t *testing.T) {
+		s := newSS()
+		snap := Snapshot(valOf(s))
+		assert.Nil(t, snap.Changed(valOf(s)))
+		assert.Nil(t, snap.Changed(valOf(*s)))
+		s.private = 1
+		assert.Nil(t, snap.Changed(valOf(s)))
+	})
+
+	t.Run("#3: changed fields", func(t *testing.T) {
+		s := newSS()
+		snap := Snapshot(valOf(s))
+		s.ID = 2
+		s.UpdatedAt = time.Now()
+		s.Tags[0] = "aa"
+		s.Attrs["k"].([]int)[0] = 2 //nolint:forcetypeassert
+		s.Home.Street = "street"
+		s.Work.City = "city3"
+		assert.Equal(t, []string{"ID", "UpdatedAt", "Tags", "Attrs", "Home.Street", "Work"},
+			snap.Changed(valOf(s)))
+	})
+
+	t.Run("#4: embedded struct pointer", func(t *testing.T) {
+		s := newSS()
+		snap := Snapshot(valOf(s))
+		s.Address = &Address{City: "city"}
+		assert.Equal(t, []string{"City", "Street"}, snap.Changed(valOf(s)))
+
+		snap = Snapshot(valOf(s))
+		s.Street = "street"
+		assert.Equal(t, []string{"Street"}, snap.Changed(valOf(s)))
+
+		s.Address = nil
+		assert.Equal(t, []string{"City", "Street"}, snap.Changed(valOf(s)))
+	})
+
+	t.Run("#5: different type", func(t *testing.T) {
+		snap := Snapshot(valOf(newSS()))
+		assert.Nil(t, snap.Changed(valOf(Address{})))
+	})
+
+	t.Run("#6: func and chan fields are not tracked", func(t *testing.T) {
+		type Handler struct {
+			Name   string
+			OnDone func()
+			Done   chan struct{}
+		}
+		h := &Handler{Name: "h", OnDone: func() {}, Done: make(chan struct{})}
+		snap := Snapshot(valOf(h))
+		assert.Nil(t, snap.Changed(valOf(h)))
+		h.OnDone = nil
+		h.Done = nil
+		h.Name = "hh"
+		assert.Equal(t, []string{"Name"}, snap.Changed(valOf(h)))
+	})
+}
@@ -1,9 +1,11 @@
 package rflutil
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"unsafe"
 )
 
@@ -20,13 +22,9 @@ func StructGetField[T any](v reflect.Value, name string, caseSensitive bool) (T,
 	if sf == nil {
 		return zeroT, fmt.Errorf("%w: field '%s' not found", ErrNotFound, name)
 	}
-	field := val.Field(sf.Index[0])
-	if !sf.IsExported() {
-		if !field.CanAddr() {
-			return zeroT, fmt.Errorf("%w: accessing unexported field requires it to be addressable",
-				ErrValueUnaddressable)
-		}
-		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem() //nolint:gosec
+	field, err := structFieldValue(val, sf)
+	if err != nil {
+		return zeroT, err
 	}
 
 	t, ok := field.Interface().(T)
@@ -38,8 +36,10 @@ func StructGetField[T any](v reflect.Value, name string, caseSensitive bool) (T,
 }
 
 // StructSetField set struct field value by field name as T type.
-func StructSetField[T any](v reflect.Value, name string, value T, caseSensitive bool) error {
-	val := indirectValueTilRoot(v)
+// With option WithAutoInit, nil pointers to the struct and nil embedded struct pointers are allocated.
+func StructSetField[T any](v reflect.Value, name string, value T, caseSensitive bool, opts ...Option) error {
+	autoInit := newOptions(opts).autoInit
+	val := indirectValueTilRootEx(v, autoInit)
 	if !val.IsValid() || val.Kind() != reflect.Struct {
 		return fmt.Errorf("%w: require struct type (got %v)", ErrTypeInvalid, v.Type())
 	}
@@ -48,13 +48,9 @@ func StructSetField[T any](v reflect.Value, name string, value T, caseSensitive
 	if sf == nil {
 		return fmt.Errorf("%w: field '%s' not found", ErrNotFound, name)
 	}
-	field := val.Field(sf.Index[0])
-	if !sf.IsExported() {
-		if !field.CanAddr() {
-			return fmt.Errorf("%w: accessing unexported field requires it to be addressable",
-				ErrValueUnaddressable)
-		}
-		field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem() //nolint:gosec
+	field, err := structFieldValueEx(val, sf, autoInit)
+	if err != nil {
+		return err
 	}
 	if !field.CanSet() {
 		return ErrValueUnsettable
@@ -70,6 +66,82 @@ func StructSetField[T any](v reflect.Value, name string, value T, caseSensitive
 	return nil
 }
 
+// structFieldValue gets value of the struct field. Unexported fields are made accessible
+// if they are addressable. Promoted fields of embedded structs are supported too.
+func structFieldValue(v reflect.Value, sf *reflect.StructField) (reflect.Value, error) {
+	return structFieldValueEx(v, sf, false)
+}
+
+// structFieldValueEx is the same as structFieldValue, but it can allocate nil embedded struct
+// pointers on the way to promoted fields when `autoInit` is true
+func structFieldValueEx(v reflect.Value, sf *reflect.StructField, autoInit bool) (reflect.Value, error) {
+	field := v
+	for i, x := range sf.Index {
+		if i > 0 && field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				if autoInit && field.CanAddr() {
+					field = makeAccessible(field)
+					field.Set(reflect.New(field.Type().Elem()))
+				} else {
+					return reflect.Value{}, fmt.Errorf("%w: field '%s' is inaccessible via nil embedded pointer",
+						ErrValueInvalid, sf.Name)
+				}
+			}
+			field = field.Elem()
+		}
+		field = field.Field(x)
+	}
+	if !field.CanInterface() {
+		if !field.CanAddr() {
+			return reflect.Value{}, fmt.Errorf("%w: accessing unexported field requires it to be addressable",
+				ErrValueUnaddressable)
+		}
+		field = makeAccessible(field)
+	}
+	return field, nil
+}
+
+// makeAccessible makes an addressable value obtained via unexported fields accessible
+func makeAccessible(v reflect.Value) reflect.Value {
+	if v.CanInterface() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem() //nolint:gosec
+}
+
+// structFieldByPath gets value of a field by path such as "Profile.LastName", pointers and interfaces
+// on the path are dereferenced. Empty path means the input itself. When a nil pointer is met,
+// an invalid value is returned without error.
+func structFieldByPath(v reflect.Value, path string) (reflect.Value, error) {
+	if path == "" {
+		return v, nil
+	}
+	val := v
+	for _, name := range strings.Split(path, ".") {
+		val = indirectValueTilRoot(val)
+		if !val.IsValid() {
+			return val, nil
+		}
+		if val.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("%w: require struct type for '%s' of path '%s' (got %v)",
+				ErrTypeInvalid, name, path, val.Type())
+		}
+		sf := structGetField(val, name, true)
+		if sf == nil {
+			return reflect.Value{}, fmt.Errorf("%w: field '%s' of path '%s' not found", ErrNotFound, name, path)
+		}
+		field, err := structFieldValue(val, sf)
+		if err != nil {
+			if errors.Is(err, ErrValueInvalid) {
+				return reflect.Value{}, nil // nil embedded pointer
+			}
+			return reflect.Value{}, err
+		}
+		val = field
+	}
+	return val, nil
+}
+
 func structGetField(v reflect.Value, name string, caseSensitive bool) *reflect.StructField {
 	if caseSensitive {
 		f, ok := v.Type().FieldByName(name)
@@ -94,10 +166,24 @@ func StructListFields(
 	v reflect.Value,
 	flattenEmbeddedStructs bool,
 ) ([]string, error) {
-	return structListFields(v.Type(), flattenEmbeddedStructs)
+	fields, err := structListFields(v.Type(), flattenEmbeddedStructs)
+	if err != nil {
+		return nil, err
+	}
+	// Returns a copy as the result is cached
+	return append(make([]string, 0, len(fields)), fields...), nil
+}
+
+type structListFieldsCacheKey struct {
+	typ                    reflect.Type
+	flattenEmbeddedStructs bool
 }
 
+// structListFieldsCache caches results of structListFields by struct type
+var structListFieldsCache sync.Map
+
 // structListFields lists all fields of a struct with flattening embedded structs option.
+// The result is cached and must not be modified.
 func structListFields(
 	t reflect.Type,
 	flattenEmbeddedStructs bool,
@@ -107,6 +193,11 @@ func structListFields(
 		return nil, fmt.Errorf("%w: struct or struct pointer required, got '%v'", ErrTypeInvalid, t)
 	}
 
+	cacheKey := structListFieldsCacheKey{typ: typ, flattenEmbeddedStructs: flattenEmbeddedStructs}
+	if cached, ok := structListFieldsCache.Load(cacheKey); ok {
+		return cached.([]string), nil //nolint:forcetypeassert
+	}
+
 	numFields := typ.NumField()
 	result := make([]string, 0, numFields)
 	for i := 0; i < numFields; i++ {
@@ -133,5 +224,7 @@ func structListFields(
 			result = append(result, structField.Name)
 		}
 	}
+
+	structListFieldsCache.Store(cacheKey, result)
 	return result, nil
 }
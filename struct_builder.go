@@ -0,0 +1,277 @@
+package rflutil
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StructBuilder builds struct types dynamically via reflect.StructOf, for example:
+//
+//	typ, err := NewStructBuilderFrom(reflect.TypeOf(User{})).
+//		Omit("Password").
+//		Rename("Name", "FullName").
+//		AddField("Version", reflect.TypeOf(0), `json:"version"`).
+//		Build()
+//
+// Every method returns the builder itself. Once an error occurs, the following calls are skipped
+// and the error is returned by Build().
+type StructBuilder struct {
+	fields []reflect.StructField
+	err    error
+}
+
+// NewStructBuilder creates an empty struct builder
+func NewStructBuilder() *StructBuilder {
+	return &StructBuilder{}
+}
+
+// NewStructBuilderFrom creates a struct builder having the exported fields of the given struct type.
+// Unexported fields are skipped as reflect.StructOf doesn't support them.
+func NewStructBuilderFrom(t reflect.Type) *StructBuilder {
+	b := &StructBuilder{}
+	typ := indirectTypeTilRoot(t)
+	if typ.Kind() != reflect.Struct {
+		b.err = fmt.Errorf("%w: require struct type (got %v)", ErrTypeInvalid, t)
+		return b
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		b.fields = append(b.fields, reflect.StructField{
+			Name:      sf.Name,
+			Type:      sf.Type,
+			Tag:       sf.Tag,
+			Anonymous: sf.Anonymous,
+		})
+	}
+	return b
+}
+
+// AddField adds a field. The name must be an exported identifier.
+func (b *StructBuilder) AddField(name string, typ reflect.Type, tag reflect.StructTag) *StructBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.fields = append(b.fields, reflect.StructField{Name: name, Type: typ, Tag: tag})
+	return b
+}
+
+// AddEmbedded adds an embedded field of the given type. The type must be a named exported type
+// or a pointer to one, and its methods are not promoted (see reflect.StructOf).
+func (b *StructBuilder) AddEmbedded(typ reflect.Type, tag reflect.StructTag) *StructBuilder {
+	if b.err != nil {
+		return b
+	}
+	name := indirectTypeTilRoot(typ).Name()
+	if name == "" {
+		b.err = fmt.Errorf("%w: embedded type must be named (got %v)", ErrTypeInvalid, typ)
+		return b
+	}
+	b.fields = append(b.fields, reflect.StructField{Name: name, Type: typ, Tag: tag, Anonymous: true})
+	return b
+}
+
+// Pick keeps only the fields of the given names, the fields keep their current order
+func (b *StructBuilder) Pick(names ...string) *StructBuilder {
+	if b.err != nil || !b.checkFieldsExist(names) {
+		return b
+	}
+	fields := make([]reflect.StructField, 0, len(names))
+	for _, sf := range b.fields {
+		if sliceIndexOf(names, sf.Name) >= 0 {
+			fields = append(fields, sf)
+		}
+	}
+	b.fields = fields
+	return b
+}
+
+// Omit removes the fields of the given names
+func (b *StructBuilder) Omit(names ...string) *StructBuilder {
+	if b.err != nil || !b.checkFieldsExist(names) {
+		return b
+	}
+	fields := make([]reflect.StructField, 0, len(b.fields))
+	for _, sf := range b.fields {
+		if sliceIndexOf(names, sf.Name) < 0 {
+			fields = append(fields, sf)
+		}
+	}
+	b.fields = fields
+	return b
+}
+
+// Rename renames a field. A renamed embedded field becomes a regular field.
+func (b *StructBuilder) Rename(name, newName string) *StructBuilder {
+	if b.err != nil {
+		return b
+	}
+	if sf := b.field(name); sf != nil {
+		sf.Name = newName
+		sf.Anonymous = false
+	}
+	return b
+}
+
+// SetTag replaces the whole tag of a field
+func (b *StructBuilder) SetTag(name string, tag reflect.StructTag) *StructBuilder {
+	if b.err != nil {
+		return b
+	}
+	if sf := b.field(name); sf != nil {
+		sf.Tag = tag
+	}
+	return b
+}
+
+// SetTags rewrites the tag of the given tag name of the fields from the given tags, such as
+// the output of ParseTagsOf. Tags are matched to fields by Tag.FieldName, and are formatted
+// with Tag.Format. Other tags of the fields are kept.
+func (b *StructBuilder) SetTags(tagName, delim string, tags []*Tag) *StructBuilder {
+	if b.err != nil {
+		return b
+	}
+	for _, tag := range tags {
+		sf := b.field(tag.FieldName)
+		if sf == nil {
+			return b
+		}
+		sf.Tag = structTagSet(sf.Tag, tagName, tag.Format(delim))
+	}
+	return b
+}
+
+// Fields returns names of the current fields
+func (b *StructBuilder) Fields() []string {
+	names := make([]string, 0, len(b.fields))
+	for _, sf := range b.fields {
+		names = append(names, sf.Name)
+	}
+	return names
+}
+
+// Build builds the struct type
+func (b *StructBuilder) Build() (typ reflect.Type, err error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	names := make(map[string]struct{}, len(b.fields))
+	for _, sf := range b.fields {
+		if !token.IsIdentifier(sf.Name) || !token.IsExported(sf.Name) {
+			return nil, fmt.Errorf("%w: field name '%s' is not an exported identifier", ErrValueInvalid, sf.Name)
+		}
+		if sf.Type == nil {
+			return nil, fmt.Errorf("%w: field '%s' has no type", ErrTypeInvalid, sf.Name)
+		}
+		if _, exists := names[sf.Name]; exists {
+			return nil, fmt.Errorf("%w: field '%s'", ErrDuplicated, sf.Name)
+		}
+		names[sf.Name] = struct{}{}
+	}
+
+	// reflect.StructOf panics on unsupported fields such as some embedded types with methods
+	defer func() {
+		if r := recover(); r != nil {
+			typ, err = nil, fmt.Errorf("%w: %v", ErrTypeInvalid, r)
+		}
+	}()
+	return reflect.StructOf(b.fields), nil
+}
+
+func (b *StructBuilder) field(name string) *reflect.StructField {
+	for i := range b.fields {
+		if b.fields[i].Name == name {
+			return &b.fields[i]
+		}
+	}
+	b.err = fmt.Errorf("%w: field '%s'", ErrNotFound, name)
+	return nil
+}
+
+func (b *StructBuilder) checkFieldsExist(names []string) bool {
+	for _, name := range names {
+		if b.field(name) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// structTagSet sets value for a key of a struct tag in the conventional format `k1:"v1" k2:"v2"`.
+// The key is appended if it doesn't exist.
+func structTagSet(tag reflect.StructTag, key, value string) reflect.StructTag {
+	var sb strings.Builder
+	found := false
+	for _, kv := range structTagPairs(tag) {
+		if kv[0] == key {
+			if found {
+				continue
+			}
+			kv[1] = value
+			found = true
+		}
+		structTagWrite(&sb, kv[0], kv[1])
+	}
+	if !found {
+		structTagWrite(&sb, key, value)
+	}
+	return reflect.StructTag(sb.String())
+}
+
+func structTagWrite(sb *strings.Builder, key, value string) {
+	if sb.Len() > 0 {
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(key)
+	sb.WriteByte(':')
+	sb.WriteString(strconv.Quote(value))
+}
+
+// structTagPairs splits a struct tag into key-value pairs, the same way as reflect.StructTag.Lookup does
+func structTagPairs(tag reflect.StructTag) [][2]string {
+	var result [][2]string
+	s := string(tag)
+	for s != "" {
+		i := 0
+		for i < len(s) && s[i] == ' ' {
+			i++
+		}
+		s = s[i:]
+		if s == "" {
+			break
+		}
+
+		i = 0
+		for i < len(s) && s[i] > ' ' && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(s) || s[i] != ':' || s[i+1] != '"' {
+			break
+		}
+		key := s[:i]
+		s = s[i+1:]
+
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		value, err := strconv.Unquote(s[:i+1])
+		if err != nil {
+			break
+		}
+		s = s[i+1:]
+		result = append(result, [2]string{key, value})
+	}
+	return result
+}
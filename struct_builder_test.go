@@ -0,0 +1,142 @@
+package rflutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type BuilderBase struct {
+	ID int `json:"id"`
+}
+
+func Test_StructBuilder(t *testing.T) {
+	type User struct {
+		BuilderBase
+		Name     string `json:"name,omitempty" db:"name"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+		internal int
+	}
+
+	t.Run("#1: build from scratch", func(t *testing.T) {
+		typ, err := NewStructBuilder().
+			AddField("Name", reflect.TypeOf(""), `json:"name"`).
+			AddField("Tags", reflect.TypeOf([]string{}), "").
+			AddEmbedded(reflect.TypeOf(&BuilderBase{}), "").
+			Build()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, typ.NumField())
+		assert.Equal(t, "name", typ.Field(0).Tag.Get("json"))
+		assert.True(t, typ.Field(2).Anonymous)
+
+		v := reflect.New(typ)
+		assert.Nil(t, StructSetField(v, "Name", "abc", true))
+		assert.Nil(t, StructSetField(v, "ID", 1, true, WithAutoInit()))
+		m, err := StructToMap(v, "json", true)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"name": "abc", "Tags": []string(nil), "id": 1}, m)
+	})
+
+	t.Run("#2: derive from existing type", func(t *testing.T) {
+		b := NewStructBuilderFrom(reflect.TypeOf(&User{}))
+		assert.Equal(t, []string{"BuilderBase", "Name", "Email", "Password"}, b.Fields())
+
+		typ, err := b.Omit("Password").
+			Rename("Name", "FullName").
+			AddField("Version", reflect.TypeOf(0), `json:"version"`).
+			Build()
+		assert.Nil(t, err)
+		fields, err := StructListFields(reflect.New(typ), true)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ID", "FullName", "Email", "Version"}, fields)
+		sf, _ := typ.FieldByName("FullName")
+		assert.Equal(t, reflect.StructTag(`json:"name,omitempty" db:"name"`), sf.Tag)
+	})
+
+	t.Run("#3: pick fields", func(t *testing.T) {
+		typ, err := NewStructBuilderFrom(reflect.TypeOf(User{})).Pick("Email", "Name").Build()
+		assert.Nil(t, err)
+		assert.Equal(t, 2, typ.NumField())
+		assert.Equal(t, "Name", typ.Field(0).Name)
+		assert.Equal(t, "Email", typ.Field(1).Name)
+	})
+
+	t.Run("#4: rewrite tags", func(t *testing.T) {
+		tags, err := ParseTagsOf(valOf(User{}), "json", ",")
+		assert.Nil(t, err)
+		for _, tag := range tags {
+			tag.Name = "v2_" + tag.Name
+			delete(tag.Attrs, "omitempty")
+		}
+		typ, err := NewStructBuilderFrom(reflect.TypeOf(User{})).
+			SetTags("json", ",", tags).
+			SetTag("Password", `json:"-"`).
+			Build()
+		assert.Nil(t, err)
+		sf, _ := typ.FieldByName("Name")
+		assert.Equal(t, reflect.StructTag(`json:"v2_name" db:"name"`), sf.Tag)
+		sf, _ = typ.FieldByName("Email")
+		assert.Equal(t, reflect.StructTag(`json:"v2_email"`), sf.Tag)
+		sf, _ = typ.FieldByName("Password")
+		assert.Equal(t, reflect.StructTag(`json:"-"`), sf.Tag)
+	})
+
+	t.Run("#5: add a new tag key", func(t *testing.T) {
+		tag := &Tag{Name: "full_name", FieldName: "Name", Attrs: map[string]string{"max": "10"}}
+		typ, err := NewStructBuilderFrom(reflect.TypeOf(User{})).
+			SetTags("yaml", ";", []*Tag{tag}).
+			Build()
+		assert.Nil(t, err)
+		sf, _ := typ.FieldByName("Name")
+		assert.Equal(t, reflect.StructTag(`json:"name,omitempty" db:"name" yaml:"full_name;max=10"`), sf.Tag)
+	})
+}
+
+func Test_StructBuilder_failure(t *testing.T) {
+	t.Run("#1: input is not a struct", func(t *testing.T) {
+		_, err := NewStructBuilderFrom(reflect.TypeOf(0)).Omit("A").Build()
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: field not found", func(t *testing.T) {
+		_, err := NewStructBuilder().AddField("A", reflect.TypeOf(0), "").Omit("B").Build()
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = NewStructBuilder().AddField("A", reflect.TypeOf(0), "").Rename("B", "C").Build()
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = NewStructBuilder().SetTags("json", ",", []*Tag{{FieldName: "A"}}).Build()
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#3: duplicated fields", func(t *testing.T) {
+		_, err := NewStructBuilder().
+			AddField("A", reflect.TypeOf(0), "").
+			AddField("B", reflect.TypeOf(0), "").
+			Rename("B", "A").
+			Build()
+		assert.ErrorIs(t, err, ErrDuplicated)
+	})
+
+	t.Run("#4: invalid field names", func(t *testing.T) {
+		_, err := NewStructBuilder().AddField("a", reflect.TypeOf(0), "").Build()
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		_, err = NewStructBuilder().AddField("A-B", reflect.TypeOf(0), "").Build()
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#5: invalid embedded types", func(t *testing.T) {
+		_, err := NewStructBuilder().AddEmbedded(reflect.TypeOf(struct{}{}), "").Build()
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+		_, err = NewStructBuilder().AddField("A", nil, "").Build()
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+}
+
+func Test_structTagSet(t *testing.T) {
+	assert.Equal(t, reflect.StructTag(`json:"b"`), structTagSet(`json:"a"`, "json", "b"))
+	assert.Equal(t, reflect.StructTag(`db:"x" json:"b"`), structTagSet(`db:"x"`, "json", "b"))
+	assert.Equal(t, reflect.StructTag(`json:"b"`), structTagSet(``, "json", "b"))
+	assert.Equal(t, reflect.StructTag(`a:"1" json:"\"q\"" b:"2"`),
+		structTagSet(`a:"1"   json:"x" b:"2" json:"y"`, "json", `"q"`))
+}
@@ -8,7 +8,17 @@ import (
 
 // StructToMap converts a struct to a map.
 func StructToMap(v reflect.Value, customTag string, flattenEmbeddedStructs bool) (map[string]any, error) {
-	detailsMap, err := structToMapEx(v, customTag, flattenEmbeddedStructs)
+	var customTags []string
+	if customTag != "" {
+		customTags = []string{customTag}
+	}
+	return StructToMapByTags(v, customTags, flattenEmbeddedStructs)
+}
+
+// StructToMapByTags converts a struct to a map with using a chain of tags to determine output keys.
+// For each field, the first tag present in the chain is used (see ParseTagChain).
+func StructToMapByTags(v reflect.Value, customTags []string, flattenEmbeddedStructs bool) (map[string]any, error) {
+	detailsMap, err := structToMapEx(v, customTags, flattenEmbeddedStructs)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +38,7 @@ type structFieldDetail struct {
 //nolint:gocognit,gocyclo
 func structToMapEx(
 	v reflect.Value,
-	customTag string,
+	customTags []string,
 	flattenEmbeddedStructs bool,
 ) (result map[string]*structFieldDetail, err error) {
 	val := indirectValueTilRoot(v)
@@ -36,23 +46,6 @@ func structToMapEx(
 		return nil, fmt.Errorf("%w: struct or struct pointer required, got '%v'", ErrTypeInvalid, v.Type())
 	}
 
-	parseCustomTag := func(sf *reflect.StructField, v *reflect.Value) (string, error) {
-		tag, err := ParseTag(sf, customTag, ",")
-		if err != nil {
-			if errors.Is(err, ErrNotFound) {
-				return sf.Name, nil
-			}
-			return "", err
-		}
-		if tag.Ignored || tag.Name == "" {
-			return "", nil
-		}
-		if tag.HasAttr("omitempty") && (!v.IsValid() || v.IsZero()) {
-			return "", nil
-		}
-		return tag.Name, nil
-	}
-
 	typ := val.Type()
 	numFields := typ.NumField()
 	result = make(map[string]*structFieldDetail, numFields)
@@ -60,13 +53,35 @@ func structToMapEx(
 		field := val.Field(i)
 		structField := typ.Field(i)
 
-		if structField.Anonymous && flattenEmbeddedStructs {
+		var tag *Tag
+		if len(customTags) > 0 {
+			tag, err = ParseTagChain(&structField, customTags, ",")
+			if err != nil && !errors.Is(err, ErrNotFound) {
+				return nil, err
+			}
+		}
+		isJSONTag := tag != nil && tag.Source == jsonTagName
+		if isJSONTag && structField.Tag.Get(jsonTagName) == "-" {
+			continue
+		}
+
+		inline := structField.Anonymous && flattenEmbeddedStructs
+		if tag != nil {
+			switch {
+			case tag.HasAttr("inline"):
+				inline = true
+			case isJSONTag && structField.Anonymous && tag.Name != "":
+				// encoding/json treats an embedded struct having a tag name as a regular field
+				inline = false
+			}
+		}
+		if inline {
 			if !structField.IsExported() && !field.CanAddr() {
 				continue
 			}
 			fieldRootVal := indirectValueTilRoot(field)
 			if fieldRootVal.IsValid() && fieldRootVal.Kind() == reflect.Struct {
-				embeddedFields, err := structToMapEx(fieldRootVal, customTag, flattenEmbeddedStructs)
+				embeddedFields, err := structToMapEx(fieldRootVal, customTags, flattenEmbeddedStructs)
 				if err != nil {
 					return nil, err
 				}
@@ -78,12 +93,9 @@ func structToMapEx(
 		if !structField.IsExported() {
 			continue
 		}
-		keyName := structField.Name
-		if customTag != "" {
-			keyName, err = parseCustomTag(&structField, &field)
-			if err != nil {
-				return nil, err
-			}
+		keyName, value, err := structFieldOutput(&structField, field, tag)
+		if err != nil {
+			return nil, err
 		}
 		if keyName == "" {
 			continue
@@ -91,8 +103,25 @@ func structToMapEx(
 		result[structField.Name] = &structFieldDetail{
 			Name:   structField.Name,
 			OutKey: keyName,
-			Value:  field.Interface(),
+			Value:  value,
 		}
 	}
 	return result, nil
 }
+
+// structFieldOutput returns output key and value of a struct field. Empty key means the field is omitted.
+func structFieldOutput(sf *reflect.StructField, v reflect.Value, tag *Tag) (string, any, error) {
+	if tag == nil {
+		return sf.Name, v.Interface(), nil
+	}
+	if tag.Source == jsonTagName {
+		return jsonFieldOutput(sf, v, tag)
+	}
+	if tag.Ignored || tag.Name == "" {
+		return "", nil, nil
+	}
+	if tag.HasAttr("omitempty") && (!v.IsValid() || v.IsZero()) {
+		return "", nil, nil
+	}
+	return tag.Name, v.Interface(), nil
+}
@@ -0,0 +1,159 @@
+package rflutil
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const (
+	jsonTagName = "json"
+)
+
+type zeroChecker interface {
+	IsZero() bool
+}
+
+var zeroCheckerType = reflect.TypeOf((*zeroChecker)(nil)).Elem()
+
+// jsonFieldOutput returns output key and value of a struct field the same way encoding/json does.
+// Supported tag options: `omitempty`, `omitzero`, `string`, and the `-,` literal-dash key.
+// Field values implementing json.Marshaler or encoding.TextMarshaler are marshaled.
+func jsonFieldOutput(sf *reflect.StructField, v reflect.Value, tag *Tag) (string, any, error) {
+	key := tag.Name
+	if key == "" {
+		key = sf.Name
+	}
+	if tag.HasAttr("omitempty") && jsonIsEmptyValue(v) {
+		return "", nil, nil
+	}
+	if tag.HasAttr("omitzero") && jsonIsZeroValue(v) {
+		return "", nil, nil
+	}
+
+	value, marshaled, err := jsonMarshalValue(v)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal field '%s': %w", sf.Name, err)
+	}
+	if marshaled {
+		return key, value, nil
+	}
+
+	if tag.HasAttr("string") {
+		value, quoted, err := jsonQuoteValue(v)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal field '%s': %w", sf.Name, err)
+		}
+		if quoted {
+			return key, value, nil
+		}
+	}
+	return key, v.Interface(), nil
+}
+
+// jsonMarshalValue marshals the value if it implements json.Marshaler or encoding.TextMarshaler.
+// json.Marshaler results in json.RawMessage, encoding.TextMarshaler results in string.
+func jsonMarshalValue(v reflect.Value) (any, bool, error) {
+	if isKindIn(v.Kind(), reflect.Pointer, reflect.Interface) && v.IsNil() {
+		return nil, false, nil
+	}
+	candidates := []reflect.Value{v}
+	if v.Kind() != reflect.Pointer && v.CanAddr() {
+		candidates = append(candidates, v.Addr())
+	}
+
+	for _, c := range candidates {
+		if m, ok := c.Interface().(json.Marshaler); ok {
+			b, err := m.MarshalJSON()
+			if err != nil {
+				return nil, false, err
+			}
+			return json.RawMessage(b), true, nil
+		}
+	}
+	for _, c := range candidates {
+		if m, ok := c.Interface().(encoding.TextMarshaler); ok {
+			b, err := m.MarshalText()
+			if err != nil {
+				return nil, false, err
+			}
+			return string(b), true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// jsonQuoteValue encodes a value of string, number, or bool type as a JSON string (`string` option)
+func jsonQuoteValue(v reflect.Value) (any, bool, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, true, nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return nil, false, err
+		}
+		return string(b), true, nil
+	case reflect.String:
+		b, err := json.Marshal(v.String())
+		if err != nil {
+			return nil, false, err
+		}
+		return string(b), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// jsonIsEmptyValue checks if a value is empty as encoding/json defines for `omitempty`
+func jsonIsEmptyValue(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// jsonIsZeroValue checks if a value is zero as encoding/json defines for `omitzero`.
+// The `IsZero() bool` method is used if the value implements it.
+func jsonIsZeroValue(v reflect.Value) bool {
+	if isKindIn(v.Kind(), reflect.Pointer, reflect.Interface) {
+		if v.IsNil() {
+			return true
+		}
+		if v.Kind() == reflect.Interface {
+			return false
+		}
+	}
+	if z, ok := v.Interface().(zeroChecker); ok {
+		return z.IsZero()
+	}
+	if v.Kind() != reflect.Pointer && reflect.PointerTo(v.Type()).Implements(zeroCheckerType) {
+		if !v.CanAddr() {
+			ptr := reflect.New(v.Type())
+			ptr.Elem().Set(v)
+			v = ptr.Elem()
+		}
+		return v.Addr().Interface().(zeroChecker).IsZero() //nolint:forcetypeassert
+	}
+	return v.IsZero()
+}
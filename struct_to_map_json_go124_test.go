@@ -0,0 +1,20 @@
+//go:build go1.24
+
+package rflutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertSameAsJSONMarshal checks the map encodes to the same JSON as the struct does.
+// Only checked since Go 1.24, as encoding/json supports `omitzero` since then.
+func assertSameAsJSONMarshal(t *testing.T, s any, m map[string]any) {
+	expected, err := json.Marshal(s)
+	assert.Nil(t, err)
+	actual, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
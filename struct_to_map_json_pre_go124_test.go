@@ -0,0 +1,10 @@
+//go:build !go1.24
+
+package rflutil
+
+import (
+	"testing"
+)
+
+// assertSameAsJSONMarshal is a no-op before Go 1.24, as encoding/json doesn't support `omitzero`
+func assertSameAsJSONMarshal(*testing.T, any, map[string]any) {}
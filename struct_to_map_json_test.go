@@ -0,0 +1,155 @@
+package rflutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type jsonTestText struct {
+	s string
+}
+
+func (t jsonTestText) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(t.s)), nil
+}
+
+type jsonTestPtrMarshaler struct {
+	v int
+}
+
+func (m *jsonTestPtrMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"v": %d}`, m.v)), nil
+}
+
+type jsonTestZeroer struct {
+	X int
+}
+
+func (z jsonTestZeroer) IsZero() bool {
+	return z.X <= 0
+}
+
+type jsonTestPtrZeroer struct {
+	X int
+}
+
+func (z *jsonTestPtrZeroer) IsZero() bool {
+	return z.X <= 0
+}
+
+type jsonTestErrMarshaler struct{}
+
+func (m jsonTestErrMarshaler) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("marshal error") //nolint:err113
+}
+
+func Test_StructToMap_jsonParity(t *testing.T) {
+	type Inner struct {
+		A int    `json:"a"`
+		B string `json:"b,omitempty"`
+	}
+	type SS struct {
+		Inner `json:"inner"`
+		I     int                  `json:"i,string"`
+		F     float64              `json:"f,string"`
+		B     bool                 `json:"b,string"`
+		S     string               `json:"s,string"`
+		P     *int                 `json:"p,string"`
+		Dash  int                  `json:"-,"`
+		Skip  int                  `json:"-"`
+		NoKey int                  `json:",omitempty"`
+		T     jsonTestText         `json:"t"`
+		PM    jsonTestPtrMarshaler `json:"pm"`
+		Z     jsonTestZeroer       `json:"z,omitzero"`
+		PZ    jsonTestPtrZeroer    `json:"pz,omitzero"`
+		Tm    time.Time            `json:"tm,omitzero"`
+		Tm2   time.Time            `json:"tm2"`
+		E     []int                `json:"e,omitempty"`
+		St    Inner                `json:"st,omitempty"`
+		Any   any                  `json:"any,omitempty"`
+	}
+
+	t.Run("#1: same as json.Marshal", func(t *testing.T) {
+		s := SS{
+			Inner: Inner{A: 1},
+			I:     10, F: 1.5, B: true, S: "abc", P: ptrOf(3),
+			Dash: 4, Skip: 5, NoKey: 6,
+			T:   jsonTestText{s: "text"},
+			PM:  jsonTestPtrMarshaler{v: 7},
+			Z:   jsonTestZeroer{X: -1},
+			PZ:  jsonTestPtrZeroer{X: 0},
+			Tm2: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		}
+		m, err := StructToMap(valOf(&s), "json", true)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{
+			"inner": Inner{A: 1},
+			"i":     "10",
+			"f":     "1.5",
+			"b":     "true",
+			"s":     `"abc"`,
+			"p":     "3",
+			"-":     4,
+			"NoKey": 6,
+			"t":     "TEXT",
+			"pm":    json.RawMessage(`{"v": 7}`),
+			"tm2":   json.RawMessage(`"2024-01-02T03:04:05Z"`),
+			"st":    Inner{},
+		}, m)
+
+		assertSameAsJSONMarshal(t, &s, m)
+	})
+
+	t.Run("#2: omitted and nil values", func(t *testing.T) {
+		s := SS{
+			E:   []int{1},
+			Z:   jsonTestZeroer{X: 1},
+			PZ:  jsonTestPtrZeroer{X: 1},
+			Any: 0,
+		}
+		m, err := StructToMap(valOf(s), "json", true)
+		assert.Nil(t, err)
+		assert.Nil(t, m["p"])
+		assert.Equal(t, []int{1}, m["e"])
+		assert.Equal(t, 0, m["any"])
+		assert.Equal(t, jsonTestZeroer{X: 1}, m["z"])
+		assert.Equal(t, jsonTestPtrZeroer{X: 1}, m["pz"])
+
+		// Unaddressable value, pointer receiver marshaler is not used
+		assertSameAsJSONMarshal(t, s, m)
+	})
+
+	t.Run("#3: inline", func(t *testing.T) {
+		type Sub struct {
+			X int `json:"x"`
+			Y int `json:"y"`
+		}
+		type SS struct {
+			Sub  Sub  `json:",inline"`
+			Sub2 *Sub `yaml:",inline"`
+			Z    int  `json:"z"`
+		}
+		s := SS{Sub: Sub{X: 1, Y: 2}, Sub2: &Sub{X: 3, Y: 4}, Z: 5}
+		m, err := StructToMap(valOf(&s), "json", true)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"x": 1, "y": 2, "z": 5, "Sub2": &Sub{X: 3, Y: 4}}, m)
+
+		m, err = StructToMapByTags(valOf(&s), []string{"yaml", "json"}, true)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"x": 1, "y": 2, "z": 5}, m)
+	})
+
+	t.Run("#4: marshaler error", func(t *testing.T) {
+		type SS struct {
+			M jsonTestErrMarshaler `json:"m"`
+		}
+		_, err := StructToMap(valOf(SS{}), "json", true)
+		assert.ErrorContains(t, err, "marshal error")
+	})
+}
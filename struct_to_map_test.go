@@ -53,6 +53,42 @@ func Test_StructToMap(t *testing.T) {
 	})
 }
 
+func Test_StructToMapByTags(t *testing.T) {
+	type SS1 struct {
+		I int `mapstructure:"ii" json:"i"`
+		U int `yaml:"uu" json:"u,omitempty"`
+	}
+	type SS struct {
+		SS1
+		S string `json:"s" yaml:"ss"`
+		B bool   `yaml:"-"`
+		X int
+	}
+
+	t.Run("#1: failure, input is not struct and struct pointer", func(t *testing.T) {
+		_, err := StructToMapByTags(valOf(123), []string{"json"}, true)
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+	})
+
+	t.Run("#2: success", func(t *testing.T) {
+		s := SS{SS1: SS1{I: 1}, S: "s", B: true, X: 2}
+		m, err := StructToMapByTags(valOf(&s), []string{"mapstructure", "json", "yaml"}, true)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"ii": 1, "s": "s", "X": 2}, m)
+
+		m, err = StructToMapByTags(valOf(&s), []string{"yaml", "json"}, true)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"i": 1, "uu": 0, "ss": "s", "X": 2}, m)
+	})
+
+	t.Run("#3: empty chain", func(t *testing.T) {
+		s := SS{SS1: SS1{I: 1}, S: "s", B: true, X: 2}
+		m, err := StructToMapByTags(valOf(&s), nil, true)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]any{"I": 1, "U": 0, "S": "s", "B": true, "X": 2}, m)
+	})
+}
+
 func Test_StructToMap_embeddedStruct(t *testing.T) {
 	type SS1 struct {
 		I int    `json:"i"`
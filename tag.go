@@ -4,13 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Tag struct {
 	Name      string
 	FieldName string
-	Ignored   bool // when name is "-"
+	Source    string // the tag key supplying this tag, e.g. "json"
+	Ignored   bool   // when name is "-"
 	Attrs     map[string]string
 }
 
@@ -31,36 +35,218 @@ func (tag *Tag) HasAttr(key string) bool {
 	return ok
 }
 
-// ParseTag parse tag for the given struct field
+// GetAttrInt get attribute value as int, the value can be in decimal, hex (0x), octal (0o) or binary (0b)
+func (tag *Tag) GetAttrInt(key string) (int, error) {
+	val, err := tag.getAttr(key)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.ParseInt(val, 0, strconv.IntSize)
+	if err != nil {
+		return 0, tag.attrValueErr(key, val, err)
+	}
+	return int(i), nil
+}
+
+// GetAttrFloat get attribute value as float64
+func (tag *Tag) GetAttrFloat(key string) (float64, error) {
+	val, err := tag.getAttr(key)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(val, 64) //nolint:mnd
+	if err != nil {
+		return 0, tag.attrValueErr(key, val, err)
+	}
+	return f, nil
+}
+
+// GetAttrBool get attribute value as bool. An attribute without value is considered `true`.
+func (tag *Tag) GetAttrBool(key string) (bool, error) {
+	val, err := tag.getAttr(key)
+	if err != nil {
+		return false, err
+	}
+	if val == "" {
+		return true, nil
+	}
+	b, err := strconv.ParseBool(val)
+	if err != nil {
+		return false, tag.attrValueErr(key, val, err)
+	}
+	return b, nil
+}
+
+// GetAttrDuration get attribute value as time.Duration, e.g. `timeout=1m30s`
+func (tag *Tag) GetAttrDuration(key string) (time.Duration, error) {
+	val, err := tag.getAttr(key)
+	if err != nil {
+		return 0, err
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, tag.attrValueErr(key, val, err)
+	}
+	return d, nil
+}
+
+// GetAttrList get attribute value as a list of strings. The value is split by commas and
+// can be wrapped in square brackets, e.g. `k=[a,b,c]` or `k='a,b,c'`. Items can be quoted too.
+func (tag *Tag) GetAttrList(key string) ([]string, error) {
+	val, err := tag.getAttr(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(val) >= 2 && val[0] == '[' && val[len(val)-1] == ']' {
+		val = val[1 : len(val)-1]
+	}
+	if val == "" {
+		return []string{}, nil
+	}
+	tokens, err := tokenizeTag(val, ",")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(tokens))
+	for i := range tokens {
+		result = append(result, tokens[i].Text)
+	}
+	return result, nil
+}
+
+// Format formats the tag back to a tag value such as `name,k1=v1,k2` with the given delimiter.
+// Attributes are sorted by keys, attributes having empty values are formatted without '='.
+// Keys and values which can't be parsed back as they are get quoted.
+func (tag *Tag) Format(delim string) string {
+	var sb strings.Builder
+	sb.WriteString(formatTagText(tag.Name, delim, true))
+
+	keys := make([]string, 0, len(tag.Attrs))
+	for k := range tag.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sb.WriteString(delim)
+		sb.WriteString(formatTagText(k, delim, true))
+		if v := tag.Attrs[k]; v != "" {
+			sb.WriteString("=")
+			sb.WriteString(formatTagText(v, delim, false))
+		}
+	}
+	return sb.String()
+}
+
+// formatTagText quotes the text if it is not parsed back to itself by the tokenizer
+func formatTagText(s, delim string, isKey bool) string {
+	tokens, err := tokenizeTag(s, delim)
+	if err == nil && len(tokens) == 1 && tokens[0].Text == s && (!isKey || tokens[0].EqPos < 0) {
+		return s
+	}
+	var sb strings.Builder
+	sb.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\'' || s[i] == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(s[i])
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
+
+func (tag *Tag) getAttr(key string) (string, error) {
+	val, ok := tag.Attrs[key]
+	if !ok {
+		return "", fmt.Errorf("%w: attribute '%s' of tag '%s'", ErrNotFound, key, tag.Name)
+	}
+	return val, nil
+}
+
+func (tag *Tag) attrValueErr(key, val string, err error) error {
+	return fmt.Errorf("%w: attribute '%s' has invalid value '%s' (%v)", ErrValueInvalid, key, val, err)
+}
+
+// ParseTag parse tag for the given struct field.
+// Attribute values can be quoted (k='a,b'), or grouped by brackets (k=[a,b]). Backslash escapes are
+// recognized inside quotes only (k='it\'s'), unterminated quotes and unbalanced brackets are kept as they are.
+// When the tag value is malformed (e.g. k='a'b), a *TagSyntaxError is returned.
 func ParseTag(field *reflect.StructField, tagName, delim string) (*Tag, error) {
 	tagValue, ok := field.Tag.Lookup(tagName)
 	if !ok {
 		return nil, fmt.Errorf("%w: struct tag '%s'", ErrNotFound, tagName)
 	}
 
+	tokens, err := tokenizeTag(tagValue, delim)
+	if err != nil {
+		var syntaxErr *TagSyntaxError
+		if errors.As(err, &syntaxErr) {
+			syntaxErr.TagName = tagName
+			syntaxErr.FieldName = field.Name
+		}
+		return nil, err
+	}
+
 	tag := &Tag{
 		FieldName: field.Name,
-		Attrs:     map[string]string{},
-	}
-	tags := strings.Split(tagValue, delim)
-	if len(tags) == 0 {
-		return tag, nil
+		Source:    tagName,
+		Attrs:     make(map[string]string, len(tokens)-1),
 	}
-
-	tag.Name = tags[0]
+	tag.Name = tokens[0].Text
 	tag.Ignored = tag.Name == "-"
 
-	for _, tagOpt := range tags[1:] {
-		kv := strings.SplitN(tagOpt, "=", 2) //nolint:mnd
-		if len(kv) == 1 {
-			tag.Attrs[kv[0]] = ""
-		} else {
-			tag.Attrs[kv[0]] = kv[1]
+	for i := range tokens[1:] {
+		token := &tokens[i+1]
+		val, _ := token.Value()
+		tag.Attrs[token.Key()] = val
+	}
+	return tag, nil
+}
+
+// parseAttrTag parses a tag which consists of attributes only, e.g. `validate:"required,min=1"`,
+// the tag name is empty. Returns nil if the tag is absent.
+func parseAttrTag(field *reflect.StructField, tagName, delim string) (*Tag, error) {
+	tagValue, ok := field.Tag.Lookup(tagName)
+	if !ok {
+		return nil, nil
+	}
+	tag := &Tag{FieldName: field.Name, Source: tagName, Attrs: map[string]string{}}
+	if tagValue == "" {
+		return tag, nil
+	}
+	tokens, err := tokenizeTag(tagValue, delim)
+	if err != nil {
+		var syntaxErr *TagSyntaxError
+		if errors.As(err, &syntaxErr) {
+			syntaxErr.TagName = tagName
+			syntaxErr.FieldName = field.Name
 		}
+		return nil, err
+	}
+	for i := range tokens {
+		val, _ := tokens[i].Value()
+		tag.Attrs[tokens[i].Key()] = val
 	}
 	return tag, nil
 }
 
+// ParseTagChain parse the first tag present in the given tag names for the struct field.
+// For example, with tag names ["mapstructure", "json", "yaml"], `mapstructure` tag is used if
+// it is present, otherwise `json` tag is used, and so on. Use Tag.Source to know which tag is used.
+func ParseTagChain(field *reflect.StructField, tagNames []string, delim string) (*Tag, error) {
+	for _, tagName := range tagNames {
+		tag, err := ParseTag(field, tagName, delim)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		return tag, nil
+	}
+	return nil, fmt.Errorf("%w: struct tags %v", ErrNotFound, tagNames)
+}
+
 // ParseTagOf parse tag for the struct and field name
 func ParseTagOf(v reflect.Value, fieldName, tagName, delim string) (*Tag, error) {
 	val := indirectValueTilRoot(v)
@@ -78,16 +264,22 @@ func ParseTagOf(v reflect.Value, fieldName, tagName, delim string) (*Tag, error)
 
 // ParseTagsOf parse tags of all struct fields
 func ParseTagsOf(v reflect.Value, tagName, delim string) ([]*Tag, error) {
+	return ParseTagsOfChain(v, []string{tagName}, delim)
+}
+
+// ParseTagsOfChain parse tags of all struct fields with using the first tag present in the given
+// tag names for each field (see ParseTagChain). Fields having none of the tags are skipped.
+func ParseTagsOfChain(v reflect.Value, tagNames []string, delim string) ([]*Tag, error) {
 	val := indirectValueTilRoot(v)
 	if !val.IsValid() || val.Kind() != reflect.Struct {
 		return nil, fmt.Errorf("%w: require struct type (got %v)", ErrTypeInvalid, v.Type())
 	}
 
-	numFields := v.NumField()
+	numFields := val.NumField()
 	tags := make([]*Tag, 0, numFields)
 	for i := 0; i < numFields; i++ {
-		field := v.Type().Field(i)
-		tag, err := ParseTag(&field, tagName, delim)
+		field := val.Type().Field(i)
+		tag, err := ParseTagChain(&field, tagNames, delim)
 		if err != nil {
 			if errors.Is(err, ErrNotFound) {
 				continue // This is not error, just ignore
@@ -1,7 +1,11 @@
 package rflutil
 
 import (
+	"errors"
+	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -59,6 +63,182 @@ func Test_ParseTag(t *testing.T) {
 	})
 }
 
+func Test_ParseTag_quotedValues(t *testing.T) {
+	type SS struct {
+		I int    `validate:"i,oneof='a,b,c',default=[1,2],msg='a\\'b',regexp=^\\d+$,excludesall=[("`
+		S string `validate:"s,oneof='a,b'c"`
+	}
+	v := valOf(SS{})
+
+	t.Run("#1: success", func(t *testing.T) {
+		field, _ := v.Type().FieldByName("I")
+		tag, err := ParseTag(&field, "validate", ",")
+		assert.Nil(t, err)
+		assert.Equal(t, "i", tag.Name)
+		assert.Equal(t, map[string]string{
+			"oneof":       "a,b,c",
+			"default":     "[1,2]",
+			"msg":         "a'b",
+			"regexp":      `^\d+$`,
+			"excludesall": "[(",
+		}, tag.Attrs)
+	})
+
+	t.Run("#2: syntax error", func(t *testing.T) {
+		field, _ := v.Type().FieldByName("S")
+		_, err := ParseTag(&field, "validate", ",")
+		assert.ErrorIs(t, err, ErrTagSyntax)
+		var syntaxErr *TagSyntaxError
+		assert.True(t, errors.As(err, &syntaxErr))
+		assert.Equal(t, 13, syntaxErr.Column)
+		assert.Equal(t, "validate", syntaxErr.TagName)
+		assert.Equal(t, "S", syntaxErr.FieldName)
+		assert.Equal(t, `ErrTagSyntax: unexpected 'c' after closing quote at column 13 of "s,oneof='a,b'c" `+
+			`(tag 'validate' of field 'S')`, err.Error())
+	})
+}
+
+func Test_Tag_typedAttrs(t *testing.T) {
+	type SS struct {
+		I int `mytag:"i,min=-10,hex=0x1F,f=1.5,b=false,flag,timeout=1m30s,list=[a,'b,c',d],list2='x,y',empty=,bad=abc"`
+	}
+	field, _ := valOf(SS{}).Type().FieldByName("I")
+	tag, err := ParseTag(&field, "mytag", ",")
+	assert.Nil(t, err)
+
+	t.Run("#1: int", func(t *testing.T) {
+		i, err := tag.GetAttrInt("min")
+		assert.Nil(t, err)
+		assert.Equal(t, -10, i)
+		i, err = tag.GetAttrInt("hex")
+		assert.Nil(t, err)
+		assert.Equal(t, 31, i)
+		_, err = tag.GetAttrInt("bad")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		_, err = tag.GetAttrInt("not-exist")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#2: float", func(t *testing.T) {
+		f, err := tag.GetAttrFloat("f")
+		assert.Nil(t, err)
+		assert.Equal(t, 1.5, f)
+		_, err = tag.GetAttrFloat("bad")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#3: bool", func(t *testing.T) {
+		b, err := tag.GetAttrBool("b")
+		assert.Nil(t, err)
+		assert.False(t, b)
+		b, err = tag.GetAttrBool("flag")
+		assert.Nil(t, err)
+		assert.True(t, b)
+		_, err = tag.GetAttrBool("bad")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+		_, err = tag.GetAttrBool("not-exist")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#4: duration", func(t *testing.T) {
+		d, err := tag.GetAttrDuration("timeout")
+		assert.Nil(t, err)
+		assert.Equal(t, 90*time.Second, d)
+		_, err = tag.GetAttrDuration("bad")
+		assert.ErrorIs(t, err, ErrValueInvalid)
+	})
+
+	t.Run("#5: list", func(t *testing.T) {
+		l, err := tag.GetAttrList("list")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b,c", "d"}, l)
+		l, err = tag.GetAttrList("list2")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"x", "y"}, l)
+		l, err = tag.GetAttrList("empty")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{}, l)
+		_, err = tag.GetAttrList("not-exist")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func Test_Tag_Format(t *testing.T) {
+	t.Run("#1: attributes are sorted", func(t *testing.T) {
+		tag := &Tag{Name: "name", Attrs: map[string]string{"omitempty": "", "b": "2", "a": "1"}}
+		assert.Equal(t, "name,a=1,b=2,omitempty", tag.Format(","))
+		assert.Equal(t, "name;a=1;b=2;omitempty", tag.Format(";"))
+	})
+
+	t.Run("#2: quoting", func(t *testing.T) {
+		tag := &Tag{Name: "a,b", Attrs: map[string]string{"k=1": "v", "list": "[x,y]", "q": "'s'", "r": "it's", "d": "x,y"}}
+		assert.Equal(t, `'a,b',d='x,y','k=1'=v,list=[x,y],q='\'s\'',r=it's`, tag.Format(","))
+	})
+
+	t.Run("#3: format and parse back", func(t *testing.T) {
+		type SS struct {
+			I int `mytag:"i,k1='a,b',k2=[1,2],k3=^\\d+$,flag"`
+		}
+		field, _ := valOf(SS{}).Type().FieldByName("I")
+		tag, err := ParseTag(&field, "mytag", ",")
+		assert.Nil(t, err)
+
+		field.Tag = reflect.StructTag(`mytag:` + strconv.Quote(tag.Format(",")))
+		tag2, err := ParseTag(&field, "mytag", ",")
+		assert.Nil(t, err)
+		assert.Equal(t, tag, tag2)
+	})
+}
+
+func Test_ParseTagChain(t *testing.T) {
+	type SS struct {
+		I int    `mapstructure:"ii" json:"i,omitempty"`
+		S string `json:"s,omitempty" yaml:"ss"`
+		U uint   `yaml:"u"`
+		B bool   `xml:"b"`
+		X int    `json:"x,k='a'b"`
+	}
+	v := valOf(SS{})
+	tagNames := []string{"mapstructure", "json", "yaml"}
+
+	t.Run("#1: first tag present", func(t *testing.T) {
+		field, _ := v.Type().FieldByName("I")
+		tag, err := ParseTagChain(&field, tagNames, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, "ii", tag.Name)
+		assert.Equal(t, "mapstructure", tag.Source)
+	})
+
+	t.Run("#2: fallback tags", func(t *testing.T) {
+		field, _ := v.Type().FieldByName("S")
+		tag, err := ParseTagChain(&field, tagNames, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, "s", tag.Name)
+		assert.Equal(t, "json", tag.Source)
+		assert.True(t, tag.HasAttr("omitempty"))
+
+		field, _ = v.Type().FieldByName("U")
+		tag, err = ParseTagChain(&field, tagNames, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, "u", tag.Name)
+		assert.Equal(t, "yaml", tag.Source)
+	})
+
+	t.Run("#3: no tag present", func(t *testing.T) {
+		field, _ := v.Type().FieldByName("B")
+		_, err := ParseTagChain(&field, tagNames, ",")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = ParseTagChain(&field, nil, ",")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("#4: syntax error", func(t *testing.T) {
+		field, _ := v.Type().FieldByName("X")
+		_, err := ParseTagChain(&field, tagNames, ",")
+		assert.ErrorIs(t, err, ErrTagSyntax)
+	})
+}
+
 func Test_ParseTagOf(t *testing.T) {
 	type SS struct {
 		I int    `mytag:"i,optional,k1=v1,omitempty"`
@@ -149,3 +329,32 @@ func Test_ParseTagsOf(t *testing.T) {
 		}, tags[2].Attrs)
 	})
 }
+
+func Test_ParseTagsOfChain(t *testing.T) {
+	type SS struct {
+		I int    `mapstructure:"ii" json:"i,omitempty"`
+		S string `json:"s" yaml:"ss"`
+		U uint   `yaml:"u"`
+		B bool   `xml:"b"`
+	}
+
+	t.Run("#1: success", func(t *testing.T) {
+		tags, err := ParseTagsOfChain(valOf(&SS{}), []string{"mapstructure", "json", "yaml"}, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(tags))
+		assert.Equal(t, []string{"ii", "s", "u"}, []string{tags[0].Name, tags[1].Name, tags[2].Name})
+		assert.Equal(t, []string{"mapstructure", "json", "yaml"}, []string{tags[0].Source, tags[1].Source,
+			tags[2].Source})
+	})
+
+	t.Run("#2: failure", func(t *testing.T) {
+		_, err := ParseTagsOfChain(valOf(123), []string{"json"}, ",")
+		assert.ErrorIs(t, err, ErrTypeInvalid)
+
+		type Bad struct {
+			I int `json:"i,k='a'b"`
+		}
+		_, err = ParseTagsOfChain(valOf(Bad{}), []string{"yaml", "json"}, ",")
+		assert.ErrorIs(t, err, ErrTagSyntax)
+	})
+}
@@ -0,0 +1,198 @@
+package rflutil
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TagSyntaxError describes a syntax error found when parsing a struct tag value
+type TagSyntaxError struct {
+	TagName   string // tag name such as `json`, can be empty
+	FieldName string // struct field name, can be empty
+	Value     string // raw tag value
+	Column    int    // 0-based byte offset in the raw value where the error occurs
+	Msg       string
+}
+
+func (e *TagSyntaxError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(ErrTagSyntax.Error())
+	sb.WriteString(": ")
+	sb.WriteString(e.Msg)
+	fmt.Fprintf(&sb, " at column %d of %q", e.Column, e.Value)
+	if e.TagName != "" {
+		fmt.Fprintf(&sb, " (tag '%s'", e.TagName)
+		if e.FieldName != "" {
+			fmt.Fprintf(&sb, " of field '%s'", e.FieldName)
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+func (e *TagSyntaxError) Unwrap() error {
+	return ErrTagSyntax
+}
+
+// tagToken a token from a tag value split by a delimiter
+type tagToken struct {
+	Text   string // unquoted and unescaped text
+	EqPos  int    // position of the first top-level '=' in Text, -1 if not found
+	Offset int    // byte offset of the token in the raw tag value
+}
+
+// Key returns the part before the first top-level '='
+func (t *tagToken) Key() string {
+	if t.EqPos < 0 {
+		return t.Text
+	}
+	return t.Text[:t.EqPos]
+}
+
+// Value returns the part after the first top-level '='
+func (t *tagToken) Value() (string, bool) {
+	if t.EqPos < 0 {
+		return "", false
+	}
+	return t.Text[t.EqPos+1:], true
+}
+
+var tagBracketPairs = map[byte]byte{'[': ']', '{': '}', '(': ')'}
+
+func isTagQuote(c byte) bool {
+	return c == '\'' || c == '"'
+}
+
+func isTagClosingBracket(c byte) bool {
+	return c == ']' || c == '}' || c == ')'
+}
+
+// tokenizeTag splits a tag value by the given delimiter with supporting:
+//   - quoting of a key or value: k='a,b' or k="a,b", backslash escapes are recognized inside quotes only
+//   - bracket grouping: k=[a,b], k={a,b}, k=(a,b), the content is kept as is to parse later
+//
+// Backslashes outside quotes (e.g. `regexp=^\d+$`), unterminated quotes, and unbalanced brackets are
+// kept as literal text, so such values are parsed the same as splitting by the delimiter.
+func tokenizeTag(value, delim string) ([]tagToken, error) {
+	var (
+		tokens     []tagToken
+		buf        strings.Builder
+		eqPos      = -1
+		tokenStart = 0
+		partStart  = true // at the beginning of a key or a value
+	)
+	endToken := func() {
+		tokens = append(tokens, tagToken{Text: buf.String(), EqPos: eqPos, Offset: tokenStart})
+		buf.Reset()
+		eqPos = -1
+	}
+
+	i := 0
+	for i < len(value) {
+		if delim != "" && strings.HasPrefix(value[i:], delim) {
+			endToken()
+			i += len(delim)
+			tokenStart = i
+			partStart = true
+			continue
+		}
+
+		c := value[i]
+		switch {
+		case partStart && isTagQuote(c):
+			end := findTagClosingQuote(value, i)
+			if end < 0 {
+				break
+			}
+			buf.WriteString(unescapeTagText(value[i+1 : end]))
+			i = end + 1
+			partStart = false
+			// A quoted part must be followed by a delimiter, the end, or '=' when it is a key
+			if i < len(value) && !(delim != "" && strings.HasPrefix(value[i:], delim)) &&
+				!(eqPos < 0 && value[i] == '=') {
+				return nil, &TagSyntaxError{Value: value, Column: i,
+					Msg: fmt.Sprintf("unexpected '%c' after closing quote", value[i])}
+			}
+			continue
+		case tagBracketPairs[c] != 0:
+			end := findTagClosingBracket(value, i)
+			if end < 0 {
+				break
+			}
+			// Content inside brackets is kept as is
+			buf.WriteString(value[i : end+1])
+			i = end + 1
+			partStart = false
+			continue
+		case c == '=' && eqPos < 0:
+			eqPos = buf.Len()
+			buf.WriteByte(c)
+			i++
+			partStart = true
+			continue
+		}
+		buf.WriteByte(c)
+		i++
+		partStart = false
+	}
+
+	endToken()
+	return tokens, nil
+}
+
+// findTagClosingQuote finds the position of the quote closing the one at the given position,
+// returns -1 if the quote is unterminated
+func findTagClosingQuote(s string, start int) int {
+	quote := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// findTagClosingBracket finds the position of the bracket closing the one at the given position,
+// returns -1 if the brackets are unbalanced. Quoted text inside the brackets is skipped.
+func findTagClosingBracket(s string, start int) int {
+	var closings []byte
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case tagBracketPairs[c] != 0:
+			closings = append(closings, tagBracketPairs[c])
+		case isTagClosingBracket(c):
+			if c != closings[len(closings)-1] {
+				return -1
+			}
+			closings = closings[:len(closings)-1]
+			if len(closings) == 0 {
+				return i
+			}
+		case isTagQuote(c):
+			if end := findTagClosingQuote(s, i); end >= 0 {
+				i = end
+			}
+		}
+	}
+	return -1
+}
+
+// unescapeTagText removes backslashes used for escaping
+func unescapeTagText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,89 @@
+package rflutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tokenizeTag(t *testing.T) {
+	texts := func(tokens []tagToken) []string {
+		result := make([]string, 0, len(tokens))
+		for _, tk := range tokens {
+			result = append(result, tk.Text)
+		}
+		return result
+	}
+
+	t.Run("#1: plain values", func(t *testing.T) {
+		tokens, err := tokenizeTag("name,k1=v1,omitempty", ",")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"name", "k1=v1", "omitempty"}, texts(tokens))
+		assert.Equal(t, "k1", tokens[1].Key())
+		val, ok := tokens[1].Value()
+		assert.True(t, ok)
+		assert.Equal(t, "v1", val)
+		_, ok = tokens[2].Value()
+		assert.False(t, ok)
+		assert.Equal(t, 11, tokens[2].Offset)
+	})
+
+	t.Run("#2: quoted values", func(t *testing.T) {
+		tokens, err := tokenizeTag(`name,k1='a,b',k2="c=d",'k,3'=x,k4=don't`, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"name", "k1=a,b", "k2=c=d", "k,3=x", "k4=don't"}, texts(tokens))
+		assert.Equal(t, "k,3", tokens[3].Key())
+		val, _ := tokens[2].Value()
+		assert.Equal(t, "c=d", val)
+	})
+
+	t.Run("#3: escaped values", func(t *testing.T) {
+		tokens, err := tokenizeTag(`name,k1='a\,b',k2='it\'s',k3="\"x\"",re=^\d+\\$`, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"name", "k1=a,b", "k2=it's", `k3="x"`, `re=^\d+\\$`}, texts(tokens))
+	})
+
+	t.Run("#4: bracket grouping", func(t *testing.T) {
+		tokens, err := tokenizeTag(`name,default=[1,2],m={a:[x,y]},f=(p,'q)')`, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"name", "default=[1,2]", "m={a:[x,y]}", "f=(p,'q)')"}, texts(tokens))
+	})
+
+	t.Run("#5: multi-char delimiter", func(t *testing.T) {
+		tokens, err := tokenizeTag(`a;;b='x;;y';;c`, ";;")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "b=x;;y", "c"}, texts(tokens))
+	})
+
+	t.Run("#6: empty value", func(t *testing.T) {
+		tokens, err := tokenizeTag("", ",")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{""}, texts(tokens))
+	})
+
+	t.Run("#7: unbalanced quotes and brackets are literal", func(t *testing.T) {
+		tokens, err := tokenizeTag(`name,excludesall=[(,b=[a,b},c=a],d=x'y,k='abc`, ",")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"name", "excludesall=[(", "b=[a", "b}", "c=a]", "d=x'y", "k='abc"}, texts(tokens))
+	})
+}
+
+func Test_tokenizeTag_failure(t *testing.T) {
+	cases := []struct {
+		value  string
+		column int
+	}{
+		{`name,k='a'b`, 10},
+		{`name,k="a,b"[c]`, 12},
+		{`'name'x,k=a`, 6},
+	}
+	for _, c := range cases {
+		_, err := tokenizeTag(c.value, ",")
+		assert.ErrorIs(t, err, ErrTagSyntax, c.value)
+		var syntaxErr *TagSyntaxError
+		assert.True(t, errors.As(err, &syntaxErr), c.value)
+		assert.Equal(t, c.column, syntaxErr.Column, c.value)
+		assert.Equal(t, c.value, syntaxErr.Value)
+	}
+}
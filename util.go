@@ -26,6 +26,45 @@ func indirectValueTilRoot(v reflect.Value) reflect.Value {
 	return v
 }
 
+// indirectValueTilRootEx is the same as indirectValueTilRoot, but it can allocate nil pointers
+// if they are settable when `autoInit` is true
+func indirectValueTilRootEx(v reflect.Value, autoInit bool) reflect.Value {
+	if !autoInit {
+		return indirectValueTilRoot(v)
+	}
+	for {
+		switch v.Kind() { //nolint:exhaustive
+		case reflect.Pointer:
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		case reflect.Interface:
+			v = v.Elem()
+			if !v.IsValid() {
+				return v
+			}
+		default:
+			return v
+		}
+	}
+}
+
+// initNilValue allocates the value if it is a nil map or a nil slice and it is settable
+func initNilValue(v reflect.Value) {
+	if !v.CanSet() || !isKindIn(v.Kind(), reflect.Map, reflect.Slice) || !v.IsNil() {
+		return
+	}
+	if v.Kind() == reflect.Map {
+		v.Set(reflect.MakeMap(v.Type()))
+	} else {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	}
+}
+
 func indirectTypeTilRoot(t reflect.Type) reflect.Type {
 	for t.Kind() == reflect.Pointer {
 		t = t.Elem()
@@ -50,6 +89,16 @@ func mapExtend[K comparable, V any, M ~map[K]V](m1, m2 M, newKeysOnly bool) M {
 	return m1
 }
 
+// mapExtendValue extends a map with the given entries which must have the map key and element types.
+func mapExtendValue(m reflect.Value, entries []MapEntry, newKeysOnly bool) {
+	for _, entry := range entries {
+		if newKeysOnly && m.MapIndex(entry.Key).IsValid() {
+			continue
+		}
+		m.SetMapIndex(entry.Key, entry.Value)
+	}
+}
+
 // sliceIndexOf finds index of a value in a slice.
 func sliceIndexOf[T comparable, S ~[]T](s S, v T) int {
 	for i := range s {
@@ -68,3 +117,84 @@ func sliceRemove[T comparable, S ~[]T](s S, v T) S {
 	}
 	return append(s[:i], s[i+1:]...)
 }
+
+// visitedPtr a key to track visited pointers. Pointers to a struct and to its first field
+// have the same address, so the type is needed too.
+type visitedPtr struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepCopyValue makes a deep copy of a value. Unexported struct fields are copied shallowly.
+func deepCopyValue(v reflect.Value) reflect.Value {
+	return deepCopyValueEx(v, map[visitedPtr]reflect.Value{})
+}
+
+//nolint:gocognit
+func deepCopyValueEx(v reflect.Value, visited map[visitedPtr]reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+
+	typ := v.Type()
+	switch v.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if v.IsNil() {
+			return reflect.Zero(typ)
+		}
+		key := visitedPtr{ptr: v.Pointer(), typ: typ}
+		if copied, ok := visited[key]; ok {
+			return copied
+		}
+		ret := reflect.New(typ.Elem())
+		visited[key] = ret
+		ret.Elem().Set(deepCopyValueEx(v.Elem(), visited))
+		return ret
+	case reflect.Interface:
+		ret := reflect.New(typ).Elem()
+		if !v.IsNil() {
+			ret.Set(deepCopyValueEx(v.Elem(), visited))
+		}
+		return ret
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(typ)
+		}
+		length := v.Len()
+		ret := reflect.MakeSlice(typ, length, length)
+		for i := 0; i < length; i++ {
+			ret.Index(i).Set(deepCopyValueEx(v.Index(i), visited))
+		}
+		return ret
+	case reflect.Array:
+		ret := reflect.New(typ).Elem()
+		for i := 0; i < v.Len(); i++ {
+			ret.Index(i).Set(deepCopyValueEx(v.Index(i), visited))
+		}
+		return ret
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(typ)
+		}
+		ret := reflect.MakeMapWithSize(typ, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			ret.SetMapIndex(iter.Key(), deepCopyValueEx(iter.Value(), visited))
+		}
+		return ret
+	case reflect.Struct:
+		ret := reflect.New(typ).Elem()
+		ret.Set(v)
+		for i := 0; i < typ.NumField(); i++ {
+			if typ.Field(i).IsExported() {
+				ret.Field(i).Set(deepCopyValueEx(v.Field(i), visited))
+			}
+		}
+		return ret
+	default:
+		// Copies the value as the input may be addressable and changed later
+		ret := reflect.New(typ).Elem()
+		ret.Set(v)
+		return ret
+	}
+}
@@ -55,3 +55,53 @@ func Test_sliceRemove(t *testing.T) {
 	assert.Equal(t, []int{2, 3}, sliceRemove([]int{-1, 2, 3}, -1))
 	assert.Equal(t, []int{-1, 3}, sliceRemove([]int{-1, 2, 3}, 2))
 }
+
+func Test_deepCopyValue(t *testing.T) {
+	type Sub struct {
+		M map[string][]int
+	}
+	type SS struct {
+		I   int
+		P   *Sub
+		S   []*Sub
+		A   [2][]int
+		Any any
+		u   []int
+	}
+
+	s := SS{
+		I:   1,
+		P:   &Sub{M: map[string][]int{"a": {1, 2}}},
+		S:   []*Sub{{M: map[string][]int{"b": {3}}}, nil},
+		A:   [2][]int{{4}, nil},
+		Any: []int{5},
+		u:   []int{6},
+	}
+	copied, ok := deepCopyValue(valOf(s)).Interface().(SS)
+	assert.True(t, ok)
+	assert.Equal(t, s, copied)
+
+	s.P.M["a"][0] = 11
+	s.S[0].M["b"] = nil
+	s.A[0][0] = 44
+	s.Any.([]int)[0] = 55 //nolint:forcetypeassert
+	s.u[0] = 66
+	assert.Equal(t, []int{1, 2}, copied.P.M["a"])
+	assert.Equal(t, []int{3}, copied.S[0].M["b"])
+	assert.Equal(t, 4, copied.A[0][0])
+	assert.Equal(t, []int{5}, copied.Any)
+	assert.Equal(t, []int{66}, copied.u) // unexported field is copied shallowly
+
+	// Cyclic pointers
+	type Node struct {
+		Next *Node
+	}
+	n := &Node{}
+	n.Next = n
+	copiedNode, ok := deepCopyValue(valOf(n)).Interface().(*Node)
+	assert.True(t, ok)
+	assert.True(t, copiedNode == copiedNode.Next)
+	assert.False(t, copiedNode == n)
+
+	assert.False(t, deepCopyValue(reflect.Value{}).IsValid())
+}
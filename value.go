@@ -0,0 +1,261 @@
+package rflutil
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Value a wrapper of reflect.Value providing chainable accessors, for example:
+//
+//	v := Of(&s).Field("Items").Index(2).Key("k")
+//	if err := v.Err(); err != nil { ... }
+//	val := v.Get()
+//
+// Every accessor returns a new Value. Once an error occurs, the following accessors are skipped
+// and the error is kept to be returned by Err().
+type Value struct {
+	val  reflect.Value
+	err  error
+	path string
+	opts *options
+
+	// The map and the key when the value is a map entry, used to set the entry
+	mapVal reflect.Value
+	mapKey reflect.Value
+}
+
+// Of creates a Value wrapping the given value. If x is a reflect.Value, it is wrapped as is.
+// To be able to set values, x should be a pointer.
+//
+// With option WithAutoInit, nil pointers met by Field() are allocated, and nil maps are allocated
+// when setting their entries, as long as they are settable.
+func Of(x any, opts ...Option) *Value {
+	v, ok := x.(reflect.Value)
+	if !ok {
+		v = reflect.ValueOf(x)
+	}
+	return &Value{val: v, opts: newOptions(opts)}
+}
+
+// Err returns the first error occurred
+func (v *Value) Err() error {
+	return v.err
+}
+
+// Get returns the underlying reflect.Value, it is invalid when there is an error
+func (v *Value) Get() reflect.Value {
+	if v.err != nil {
+		return reflect.Value{}
+	}
+	return v.val
+}
+
+// Interface returns the underlying value as `any`, it is nil when there is an error
+func (v *Value) Interface() any {
+	if v.err != nil || !v.val.IsValid() {
+		return nil
+	}
+	return v.val.Interface()
+}
+
+// Kind returns kind of the underlying value, it is reflect.Invalid when there is an error
+func (v *Value) Kind() reflect.Kind {
+	if v.err != nil {
+		return reflect.Invalid
+	}
+	return v.val.Kind()
+}
+
+// Len returns length of the underlying slice, array, map, string, or chan.
+// For other types, 0 is returned and the error is kept.
+func (v *Value) Len() int {
+	if v.err != nil {
+		return 0
+	}
+	if !v.val.IsValid() {
+		v.err = v.wrapErr(v.invalidErr())
+		return 0
+	}
+	val := indirectValueTilRoot(v.val)
+	if !val.IsValid() {
+		v.err = v.wrapErr(v.invalidErr())
+		return 0
+	}
+	if !isKindIn(val.Kind(), reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan) {
+		v.err = v.wrapErr(fmt.Errorf("%w: require slice, array, map, string, or chan type (got %v)",
+			ErrTypeInvalid, v.val.Type()))
+		return 0
+	}
+	return val.Len()
+}
+
+// Field accesses a struct field by name (case-sensitive). Unexported fields are accessible
+// when the struct is addressable.
+func (v *Value) Field(name string) *Value {
+	if v.err != nil {
+		return v
+	}
+	next := &Value{path: v.path + "." + name, opts: v.opts}
+	if !v.val.IsValid() {
+		next.err = next.wrapErr(v.invalidErr())
+		return next
+	}
+	val := indirectValueTilRootEx(v.val, v.opts.autoInit)
+	if !val.IsValid() {
+		next.err = next.wrapErr(v.invalidErr())
+		return next
+	}
+	if val.Kind() != reflect.Struct {
+		next.err = next.wrapErr(fmt.Errorf("%w: require struct type (got %v)", ErrTypeInvalid, v.val.Type()))
+		return next
+	}
+	sf := structGetField(val, name, true)
+	if sf == nil {
+		next.err = next.wrapErr(fmt.Errorf("%w: field '%s' not found", ErrNotFound, name))
+		return next
+	}
+	next.val, next.err = structFieldValueEx(val, sf, v.opts.autoInit)
+	if next.err != nil {
+		next.err = next.wrapErr(next.err)
+	}
+	return next
+}
+
+// Index accesses an element of a slice or an array
+func (v *Value) Index(i int) *Value {
+	if v.err != nil {
+		return v
+	}
+	next := &Value{path: v.path + "[" + strconv.Itoa(i) + "]", opts: v.opts}
+	if !v.val.IsValid() {
+		next.err = next.wrapErr(v.invalidErr())
+		return next
+	}
+	val := indirectValueTilRoot(v.val)
+	if !val.IsValid() {
+		next.err = next.wrapErr(v.invalidErr())
+		return next
+	}
+	if !isKindIn(val.Kind(), reflect.Slice, reflect.Array) {
+		next.err = next.wrapErr(fmt.Errorf("%w: require slice or array type (got %v)",
+			ErrTypeInvalid, v.val.Type()))
+		return next
+	}
+	if i < 0 || i >= val.Len() {
+		next.err = next.wrapErr(fmt.Errorf("%w: index %d is out of range", ErrIndexOutOfRange, i))
+		return next
+	}
+	next.val = val.Index(i)
+	return next
+}
+
+// Key accesses an entry of a map
+func (v *Value) Key(k any) *Value {
+	if v.err != nil {
+		return v
+	}
+	next := &Value{path: fmt.Sprintf("%s[%v]", v.path, k), opts: v.opts}
+	if !v.val.IsValid() {
+		next.err = next.wrapErr(v.invalidErr())
+		return next
+	}
+	val := indirectValueTilRootEx(v.val, v.opts.autoInit)
+	if !val.IsValid() {
+		next.err = next.wrapErr(v.invalidErr())
+		return next
+	}
+	if val.Kind() != reflect.Map {
+		next.err = next.wrapErr(fmt.Errorf("%w: require map type (got %v)", ErrTypeInvalid, v.val.Type()))
+		return next
+	}
+	keyVal, err := valueToAssign(k, val.Type().Key())
+	if err != nil {
+		next.err = next.wrapErr(fmt.Errorf("%w: key type is %v (expect %v)", ErrTypeUnmatched,
+			reflect.TypeOf(k), val.Type().Key()))
+		return next
+	}
+	next.mapVal = val
+	next.mapKey = keyVal
+	next.val = val.MapIndex(keyVal)
+	if !next.val.IsValid() {
+		next.err = next.wrapErr(fmt.Errorf("%w: key '%v' not found", ErrNotFound, k))
+		// Keeps the map and key to allow setting a new entry
+		return next
+	}
+	return next
+}
+
+// Set sets the underlying value. The value must be settable or be a map entry.
+// Setting a new map entry is allowed even when Key() reports ErrNotFound.
+func (v *Value) Set(x any) error {
+	if v.err != nil && !v.isMissingMapEntry() {
+		return v.err
+	}
+
+	if v.mapVal.IsValid() {
+		elemVal, err := valueToAssign(x, v.mapVal.Type().Elem())
+		if err != nil {
+			return v.wrapErr(err)
+		}
+		if v.mapVal.IsNil() {
+			if v.opts.autoInit {
+				initNilValue(v.mapVal)
+			}
+			if v.mapVal.IsNil() {
+				return v.wrapErr(fmt.Errorf("%w: map is nil", ErrValueInvalid))
+			}
+		}
+		v.mapVal.SetMapIndex(v.mapKey, elemVal)
+		return nil
+	}
+
+	if !v.val.IsValid() || !v.val.CanSet() {
+		return v.wrapErr(ErrValueUnsettable)
+	}
+	val, err := valueToAssign(x, v.val.Type())
+	if err != nil {
+		return v.wrapErr(err)
+	}
+	v.val.Set(val)
+	return nil
+}
+
+// invalidErr returns the error of accessing an invalid value, or a nil pointer or interface
+func (v *Value) invalidErr() error {
+	if !v.val.IsValid() {
+		return fmt.Errorf("%w: value is invalid", ErrValueInvalid)
+	}
+	return fmt.Errorf("%w: value of %v is nil", ErrValueInvalid, v.val.Type())
+}
+
+func (v *Value) isMissingMapEntry() bool {
+	return v.mapVal.IsValid() && !v.val.IsValid()
+}
+
+func (v *Value) wrapErr(err error) error {
+	if v.path == "" {
+		return err
+	}
+	return fmt.Errorf("%w (path '%s')", err, v.path)
+}
+
+// valueToAssign gets reflect.Value of x which is assignable to the target type
+func valueToAssign(x any, targetType reflect.Type) (reflect.Value, error) {
+	val, ok := x.(reflect.Value)
+	if !ok {
+		val = reflect.ValueOf(x)
+	}
+	if !val.IsValid() {
+		if isKindIn(targetType.Kind(), reflect.Interface, reflect.Pointer, reflect.Map,
+			reflect.Slice, reflect.Func, reflect.Chan) {
+			return reflect.Zero(targetType), nil
+		}
+		return reflect.Value{}, fmt.Errorf("%w: value type is nil (expect %v)", ErrTypeUnmatched, targetType)
+	}
+	if !val.Type().AssignableTo(targetType) {
+		return reflect.Value{}, fmt.Errorf("%w: value type is %v (expect %v)",
+			ErrTypeUnmatched, val.Type(), targetType)
+	}
+	return val, nil
+}
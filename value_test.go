@@ -0,0 +1,122 @@
+package rflutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Value(t *testing.T) {
+	type Item struct {
+		Name  string
+		Attrs map[string]any
+		Nums  []int
+		u     int
+	}
+	type SS struct {
+		Items []*Item
+		Map   map[string]Item
+		P     *Item
+	}
+	newSS := func() *SS {
+		return &SS{
+			Items: []*Item{
+				{Name: "a"},
+				{Name: "b", Attrs: map[string]any{"k": []int{1, 2, 3}}, u: 10},
+			},
+			Map: map[string]Item{"x": {Name: "x", Nums: []int{4, 5}}},
+		}
+	}
+
+	t.Run("#1: get", func(t *testing.T) {
+		s := newSS()
+		v := Of(s).Field("Items").Index(1).Field("Attrs").Key("k").Index(2)
+		assert.Nil(t, v.Err())
+		assert.Equal(t, 3, v.Interface())
+		assert.Equal(t, 3, v.Get().Interface())
+		assert.Equal(t, reflect.Int, v.Kind())
+
+		assert.Equal(t, 3, Of(s).Field("Items").Index(1).Field("Attrs").Key("k").Len())
+		assert.Equal(t, 2, Of(valOf(s)).Field("Map").Key("x").Field("Nums").Len())
+		assert.Equal(t, 10, Of(s).Field("Items").Index(1).Field("u").Interface())
+	})
+
+	t.Run("#2: set", func(t *testing.T) {
+		s := newSS()
+		assert.Nil(t, Of(s).Field("Items").Index(0).Field("Name").Set("aa"))
+		assert.Equal(t, "aa", s.Items[0].Name)
+		assert.Nil(t, Of(s).Field("Items").Index(1).Field("u").Set(11))
+		assert.Equal(t, 11, s.Items[1].u)
+		assert.Nil(t, Of(s).Field("Items").Index(1).Field("Attrs").Key("k").Set(nil))
+		assert.Nil(t, s.Items[1].Attrs["k"])
+		assert.Nil(t, Of(s).Field("Items").Index(1).Field("Attrs").Key("new").Set("v"))
+		assert.Equal(t, "v", s.Items[1].Attrs["new"])
+		assert.Nil(t, Of(s).Field("Map").Key("y").Set(Item{Name: "y"}))
+		assert.Equal(t, "y", s.Map["y"].Name)
+		assert.Nil(t, Of(s).Field("P").Set(&Item{Name: "p"}))
+		assert.Equal(t, "p", s.P.Name)
+	})
+
+	t.Run("#3: errors", func(t *testing.T) {
+		s := newSS()
+		v := Of(s).Field("Items").Index(5).Field("Name")
+		assert.ErrorIs(t, v.Err(), ErrIndexOutOfRange)
+		assert.ErrorContains(t, v.Err(), "path '.Items[5]'")
+		assert.False(t, v.Get().IsValid())
+		assert.Nil(t, v.Interface())
+		assert.Equal(t, reflect.Invalid, v.Kind())
+		assert.Equal(t, 0, v.Len())
+
+		assert.ErrorIs(t, Of(s).Field("X").Err(), ErrNotFound)
+		assert.ErrorIs(t, Of(s).Field("Items").Field("X").Err(), ErrTypeInvalid)
+		assert.ErrorIs(t, Of(s).Field("Map").Index(0).Err(), ErrTypeInvalid)
+		assert.ErrorIs(t, Of(s).Field("Items").Key(0).Err(), ErrTypeInvalid)
+		assert.ErrorIs(t, Of(s).Field("Map").Key(1).Err(), ErrTypeUnmatched)
+		assert.ErrorIs(t, Of(s).Field("Map").Key("z").Err(), ErrNotFound)
+		assert.ErrorIs(t, Of(s).Field("Map").Key("z").Field("Name").Err(), ErrNotFound)
+		assert.ErrorIs(t, Of(s).Field("P").Field("Name").Err(), ErrValueInvalid)
+		assert.ErrorContains(t, Of(s).Field("P").Field("Name").Err(), "path '.P.Name'")
+		assert.ErrorIs(t, Of(*s.Items[1]).Field("u").Err(), ErrValueUnaddressable)
+	})
+
+	t.Run("#4: set errors", func(t *testing.T) {
+		s := newSS()
+		assert.ErrorIs(t, Of(*s).Field("P").Set(&Item{}), ErrValueUnsettable)
+		assert.ErrorIs(t, Of(s).Field("Map").Key("x").Field("Name").Set("xx"), ErrValueUnsettable)
+		assert.ErrorIs(t, Of(s).Field("Items").Index(0).Field("Name").Set(1), ErrTypeUnmatched)
+		assert.ErrorIs(t, Of(s).Field("Items").Index(0).Field("Name").Set(nil), ErrTypeUnmatched)
+		assert.ErrorIs(t, Of(s).Field("Map").Key("x").Set("x"), ErrTypeUnmatched)
+		assert.ErrorIs(t, Of(s).Field("X").Set(1), ErrNotFound)
+		assert.ErrorIs(t, Of(s).Field("Items").Index(0).Field("Attrs").Key("k").Set(1), ErrValueInvalid)
+	})
+
+	t.Run("#5: length", func(t *testing.T) {
+		s := newSS()
+		v := Of(s).Field("Items").Index(0)
+		assert.Equal(t, 0, v.Len())
+		assert.ErrorIs(t, v.Err(), ErrTypeInvalid)
+		assert.Equal(t, 2, Of(s).Field("Items").Len())
+		assert.Equal(t, 1, Of(s).Field("Items").Index(0).Field("Name").Len())
+	})
+
+	t.Run("#6: invalid values", func(t *testing.T) {
+		v := Of(nil)
+		assert.Equal(t, 0, v.Len())
+		assert.ErrorIs(t, v.Err(), ErrValueInvalid)
+		assert.ErrorIs(t, Of(nil).Field("Name").Err(), ErrValueInvalid)
+		assert.ErrorIs(t, Of(nil).Index(0).Err(), ErrValueInvalid)
+		assert.ErrorIs(t, Of(nil).Key("k").Err(), ErrValueInvalid)
+		assert.ErrorIs(t, Of(reflect.Value{}).Field("Name").Err(), ErrValueInvalid)
+
+		// Accessing via a nil pointer field
+		s := newSS()
+		v = Of(s).Field("P")
+		assert.Equal(t, 0, v.Len())
+		assert.ErrorIs(t, v.Err(), ErrValueInvalid)
+		assert.ErrorIs(t, Of(s).Field("P").Field("Nums").Index(0).Err(), ErrValueInvalid)
+		assert.ErrorIs(t, Of(s).Field("P").Field("Attrs").Key("k").Err(), ErrValueInvalid)
+		assert.ErrorIs(t, Of(s).Field("Items").Index(1).Field("Nums").Index(0).Err(), ErrIndexOutOfRange)
+		assert.ErrorIs(t, Of(s).Field("Items").Index(0).Field("Attrs").Key("k").Err(), ErrNotFound)
+	})
+}